@@ -0,0 +1,103 @@
+package conduit
+
+import (
+	"context"
+)
+
+// Reduce returns a channel that emits the result of combining all values from
+// the input stream with fn, once the stream closes. The first value is used as
+// the initial accumulator. If the stream is empty or the context is canceled,
+// the channel will be closed without emitting any values.
+// See [Fold] for reducing with an initial value.
+func Reduce[T any](ctx context.Context, stream <-chan T, fn func(ctx context.Context, acc, v T) T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, max(o.buffer, 1))
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		var (
+			acc  T
+			seen bool
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					// The stream may have closed because the context was
+					// canceled, in which case acc is incomplete.
+					if seen && ctx.Err() == nil {
+						out <- acc
+					}
+					return
+				}
+				if seen {
+					acc = fn(ctx, acc, v)
+				} else {
+					acc, seen = v, true
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Fold returns a channel that emits the result of combining seed and all
+// values from the input stream with fn, once the stream closes. If the stream
+// is empty, seed is emitted. If the context is canceled, the channel will be
+// closed without emitting any values.
+// See [Scan] for emitting every intermediate result.
+func Fold[T, U any](ctx context.Context, stream <-chan T, seed U, fn func(ctx context.Context, acc U, v T) U, opts ...Option) <-chan U {
+	o := newOptions(opts)
+	out := make(chan U, max(o.buffer, 1))
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		acc := seed
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					if ctx.Err() == nil {
+						out <- acc
+					}
+					return
+				}
+				acc = fn(ctx, acc, v)
+			}
+		}
+	}()
+	return out
+}
+
+// Scan returns a channel that emits the running result of combining seed and
+// the values from the input stream with fn, after every value.
+func Scan[T, U any](ctx context.Context, stream <-chan T, seed U, fn func(ctx context.Context, acc U, v T) U, opts ...Option) <-chan U {
+	o := newOptions(opts)
+	out := make(chan U, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		acc := seed
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					return
+				}
+				acc = fn(ctx, acc, v)
+				select {
+				case <-ctx.Done():
+					return
+				case out <- acc:
+				}
+			}
+		}
+	}()
+	return out
+}
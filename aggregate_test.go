@@ -0,0 +1,64 @@
+package conduit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	sum := func(_ context.Context, acc, v int) int { return acc + v }
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "Reduce",
+			setup: func(ctx context.Context) <-chan int {
+				return Reduce(ctx, From(ctx, 1, 2, 3), sum)
+			},
+			want: []int{6},
+		},
+		{
+			name: "Reduce empty",
+			setup: func(ctx context.Context) <-chan int {
+				return Reduce(ctx, From[int](ctx), sum)
+			},
+			want: []int{},
+		},
+		{
+			name: "Fold",
+			setup: func(ctx context.Context) <-chan int {
+				return Fold(ctx, From(ctx, 1, 2, 3), 10, sum)
+			},
+			want: []int{16},
+		},
+		{
+			name: "Scan",
+			setup: func(ctx context.Context) <-chan int {
+				return Scan(ctx, From(ctx, 1, 2, 3), 0, sum)
+			},
+			want: []int{1, 3, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+func TestFoldEmpty(t *testing.T) {
+	ctx := t.Context()
+	got, ok := <-Fold(ctx, From[string](ctx), "seed", func(_ context.Context, acc, v string) string {
+		return acc + v
+	})
+	if !ok || got != "seed" {
+		t.Errorf("got %q, %v, want %q, true", got, ok, "seed")
+	}
+}
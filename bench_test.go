@@ -3,6 +3,8 @@ package conduit
 import (
 	"context"
 	"math/rand/v2"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 )
@@ -59,4 +61,42 @@ func BenchmarkConcurrency(b *testing.B) {
 			_ = results
 		}
 	})
+
+	b.Run("ParallelMap", func(b *testing.B) {
+		for b.Loop() {
+			stream := FromSeq(ctx, func(yield func(uint) bool) {
+				for i := range uint(totalCount) {
+					if !yield(i) {
+						return
+					}
+				}
+			})
+			results := collect(ctx, ParallelMap(ctx, stream, 10000, processFunc), totalCount)
+			_ = results
+		}
+	})
+}
+
+func BenchmarkBroadcast(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range []uint{2, 8} {
+		b.Run("outputs="+strconv.Itoa(int(n)), func(b *testing.B) {
+			b.ReportAllocs()
+			in := make(chan int)
+			var wg sync.WaitGroup
+			for _, out := range Broadcast(ctx, in, n) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range out {
+					}
+				}()
+			}
+			for i := 0; b.Loop(); i++ {
+				in <- i
+			}
+			close(in)
+			wg.Wait()
+		})
+	}
 }
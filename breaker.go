@@ -0,0 +1,245 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a function wrapped with [CircuitBreaker] when
+// the breaker rejects a call.
+var ErrCircuitOpen = errors.New("conduit: circuit breaker is open")
+
+// A BreakerState is the state of a [Breaker].
+type BreakerState int
+
+const (
+	// BreakerClosed lets calls through, and tracks how many of them fail.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects calls until the cooldown has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of trial calls through, to find
+	// out whether the downstream service has recovered.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "BreakerState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// A BreakerPolicy configures a [Breaker].
+type BreakerPolicy struct {
+	// FailureRate is the fraction of failed calls, in (0, 1], at which the
+	// breaker opens. If it is 0, 0.5 is used.
+	FailureRate float64
+
+	// MinCalls is the number of calls the breaker must have seen within the
+	// window before it can open, so that a few early failures do not trip
+	// it. If it is 0, 10 is used; set it to 1 to open on the first failure.
+	MinCalls uint
+
+	// Window is the length of the rolling window over which the failure rate
+	// is measured. If it is 0, 10 seconds is used.
+	Window time.Duration
+
+	// Cooldown is how long the breaker stays open before letting trial calls
+	// through. If it is 0, the window length is used.
+	Cooldown time.Duration
+
+	// HalfOpenCalls is the number of trial calls let through while half-open.
+	// The breaker closes once they have all succeeded, and opens again as
+	// soon as one fails. If it is 0, it is treated as 1.
+	HalfOpenCalls uint
+
+	// IsFailure reports whether a call that returned err counts as a
+	// failure; other calls count as successes. If it is nil, every non-nil
+	// error is a failure.
+	IsFailure func(err error) bool
+}
+
+// breakerBuckets is the number of buckets the rolling window is divided into.
+const breakerBuckets = 10
+
+type breakerBucket struct {
+	epoch           int64
+	calls, failures uint
+}
+
+// A Breaker is a circuit breaker: it tracks the outcome of calls to a
+// downstream service, and once too many of them fail, it rejects further
+// calls for a while instead of letting them pile up. A Breaker is safe for
+// concurrent use, so a single Breaker can guard calls from several stages.
+// See [CircuitBreaker].
+type Breaker struct {
+	policy BreakerPolicy
+	width  time.Duration // of a bucket
+
+	mu       sync.Mutex
+	state    BreakerState
+	gen      uint64 // incremented on every state change
+	buckets  [breakerBuckets]breakerBucket
+	openedAt time.Time
+	trials   uint // calls let through while half-open
+	passed   uint // trial calls that succeeded
+}
+
+// NewBreaker returns a closed [Breaker] configured by policy.
+func NewBreaker(policy BreakerPolicy) *Breaker {
+	if policy.MinCalls == 0 {
+		policy.MinCalls = 10
+	}
+	if policy.FailureRate == 0 {
+		policy.FailureRate = 0.5
+	}
+	if policy.Window == 0 {
+		policy.Window = 10 * time.Second
+	}
+	if policy.Cooldown == 0 {
+		policy.Cooldown = policy.Window
+	}
+	policy.HalfOpenCalls = max(policy.HalfOpenCalls, 1)
+	return &Breaker{
+		policy: policy,
+		width:  max(policy.Window/breakerBuckets, 1),
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cool(time.Now())
+	return b.state
+}
+
+// cool moves an open breaker to half-open once the cooldown has passed.
+func (b *Breaker) cool(now time.Time) {
+	if b.state == BreakerOpen && now.Sub(b.openedAt) >= b.policy.Cooldown {
+		b.setState(BreakerHalfOpen, now)
+	}
+}
+
+func (b *Breaker) setState(s BreakerState, now time.Time) {
+	b.state = s
+	b.gen++
+	b.trials, b.passed = 0, 0
+	b.buckets = [breakerBuckets]breakerBucket{}
+	if s == BreakerOpen {
+		b.openedAt = now
+	}
+}
+
+// allow reports whether a call may go ahead, and if so, returns the
+// generation to pass to [Breaker.record].
+func (b *Breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cool(time.Now())
+	switch b.state {
+	case BreakerOpen:
+		return 0, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.trials == b.policy.HalfOpenCalls {
+			return 0, ErrCircuitOpen
+		}
+		b.trials++
+	}
+	return b.gen, nil
+}
+
+// A callOutcome is the outcome of a call let through by a [Breaker].
+type callOutcome int
+
+const (
+	callSucceeded callOutcome = iota
+	callFailed
+	callAbandoned // the caller's context was done; the call is not counted
+)
+
+// record records the outcome of a call let through in generation gen.
+// Outcomes of calls let through before the last state change are ignored.
+func (b *Breaker) record(gen uint64, outcome callOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.gen {
+		return
+	}
+	now := time.Now()
+	switch b.state {
+	case BreakerClosed:
+		if outcome == callAbandoned {
+			return
+		}
+		epoch := now.UnixNano() / int64(b.width)
+		bucket := &b.buckets[epoch%breakerBuckets]
+		if bucket.epoch != epoch {
+			*bucket = breakerBucket{epoch: epoch}
+		}
+		bucket.calls++
+		if outcome == callFailed {
+			bucket.failures++
+		}
+		var calls, failures uint
+		for _, bk := range b.buckets {
+			if bk.epoch > epoch-breakerBuckets {
+				calls += bk.calls
+				failures += bk.failures
+			}
+		}
+		if calls >= b.policy.MinCalls && float64(failures) >= b.policy.FailureRate*float64(calls) {
+			b.setState(BreakerOpen, now)
+		}
+	case BreakerHalfOpen:
+		switch outcome {
+		case callFailed:
+			b.setState(BreakerOpen, now)
+		case callAbandoned:
+			b.trials--
+		default:
+			b.passed++
+			if b.passed == b.policy.HalfOpenCalls {
+				b.setState(BreakerClosed, now)
+			}
+		}
+	}
+}
+
+// CircuitBreaker returns a function that calls fn through b. While b is
+// open, or half-open with all its trial calls under way, the function fails
+// immediately with [ErrCircuitOpen] instead of calling fn. It is meant to wrap
+// the function passed to a fallible stage: with [MapDeadLetter], rejected
+// values are routed to the dead-letter output, where they can be handled by
+// a fallback; with [TryMap], the first rejection stops the pipeline.
+//
+// Calls that return after the caller's context is done are not counted, and
+// a call that panics counts as a failure.
+func CircuitBreaker[T, U any](b *Breaker, fn func(context.Context, T) (U, error)) func(context.Context, T) (U, error) {
+	return func(ctx context.Context, v T) (U, error) {
+		gen, err := b.allow()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		outcome := callFailed
+		defer func() { b.record(gen, outcome) }()
+		val, err := fn(ctx, v)
+		switch {
+		case ctx.Err() != nil:
+			outcome = callAbandoned
+		case err == nil, b.policy.IsFailure != nil && !b.policy.IsFailure(err):
+			outcome = callSucceeded
+		}
+		return val, err
+	}
+}
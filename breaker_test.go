@@ -0,0 +1,204 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errDown := errors.New("down")
+	errNotFound := errors.New("not found")
+
+	tests := []struct {
+		name      string
+		policy    BreakerPolicy
+		results   []error // returned by successive calls to fn
+		wantState BreakerState
+		wantCalls int
+	}{
+		{
+			name:      "stays closed below failure rate",
+			policy:    BreakerPolicy{FailureRate: 0.5, MinCalls: 4},
+			results:   []error{nil, errDown, nil, nil, errDown},
+			wantState: BreakerClosed,
+			wantCalls: 5,
+		},
+		{
+			name:      "opens at failure rate",
+			policy:    BreakerPolicy{FailureRate: 0.5, MinCalls: 4},
+			results:   []error{nil, errDown, nil, errDown, nil, nil},
+			wantState: BreakerOpen,
+			wantCalls: 4,
+		},
+		{
+			name:      "waits for min calls",
+			policy:    BreakerPolicy{MinCalls: 3},
+			results:   []error{errDown, errDown},
+			wantState: BreakerClosed,
+			wantCalls: 2,
+		},
+		{
+			name:      "default min calls",
+			results:   []error{errDown, errDown, errDown, errDown, errDown, errDown, errDown, errDown, errDown},
+			wantState: BreakerClosed,
+			wantCalls: 9,
+		},
+		{
+			name: "ignores errors that are not failures",
+			policy: BreakerPolicy{IsFailure: func(err error) bool {
+				return !errors.Is(err, errNotFound)
+			}},
+			results:   []error{errNotFound, errNotFound, errNotFound},
+			wantState: BreakerClosed,
+			wantCalls: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(tt.policy)
+			calls := 0
+			call := CircuitBreaker(b, func(_ context.Context, i int) (int, error) {
+				calls++
+				return i, tt.results[i]
+			})
+			for i := range tt.results {
+				if _, err := call(t.Context(), i); err != nil && !errors.Is(err, tt.results[i]) && !errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("call %d: got error %v", i, err)
+				}
+			}
+			if got := b.State(); got != tt.wantState {
+				t.Errorf("got state %v, want %v", got, tt.wantState)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	t.Run("rolling window", func(t *testing.T) {
+		b := NewBreaker(BreakerPolicy{MinCalls: 2, Window: 50 * time.Millisecond})
+		call := CircuitBreaker(b, func(context.Context, int) (int, error) { return 0, errDown })
+		call(t.Context(), 0)
+		time.Sleep(60 * time.Millisecond)
+		call(t.Context(), 0)
+		if got := b.State(); got != BreakerClosed {
+			t.Errorf("got state %v, want %v once the first failure left the window", got, BreakerClosed)
+		}
+	})
+
+	t.Run("half-open", func(t *testing.T) {
+		const cooldown = 20 * time.Millisecond
+		b := NewBreaker(BreakerPolicy{MinCalls: 1, Cooldown: cooldown, HalfOpenCalls: 2})
+		var fail bool
+		call := CircuitBreaker(b, func(context.Context, int) (int, error) {
+			if fail {
+				return 0, errDown
+			}
+			return 0, nil
+		})
+		fail = true
+		call(t.Context(), 0)
+		if _, err := call(t.Context(), 0); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+		}
+
+		// A failed trial call opens the breaker again.
+		time.Sleep(cooldown)
+		if got := b.State(); got != BreakerHalfOpen {
+			t.Fatalf("got state %v, want %v after the cooldown", got, BreakerHalfOpen)
+		}
+		call(t.Context(), 0)
+		if got := b.State(); got != BreakerOpen {
+			t.Fatalf("got state %v, want %v after a failed trial", got, BreakerOpen)
+		}
+
+		// The breaker closes once all trial calls have succeeded.
+		time.Sleep(cooldown)
+		fail = false
+		call(t.Context(), 0)
+		if got := b.State(); got != BreakerHalfOpen {
+			t.Fatalf("got state %v, want %v after one of two trials", got, BreakerHalfOpen)
+		}
+		call(t.Context(), 0)
+		if got := b.State(); got != BreakerClosed {
+			t.Errorf("got state %v, want %v after all trials", got, BreakerClosed)
+		}
+	})
+
+	t.Run("half-open limits trial calls", func(t *testing.T) {
+		b := NewBreaker(BreakerPolicy{MinCalls: 1, Cooldown: time.Millisecond})
+		release := make(chan struct{})
+		call := CircuitBreaker(b, func(ctx context.Context, v int) (int, error) {
+			if v == 0 {
+				return 0, errDown
+			}
+			<-release
+			return v, nil
+		})
+		call(t.Context(), 0)
+		time.Sleep(time.Millisecond)
+		done := make(chan error)
+		go func() {
+			_, err := call(t.Context(), 1)
+			done <- err
+		}()
+		// Wait for the trial call to be let through.
+		for {
+			b.mu.Lock()
+			trials := b.trials
+			b.mu.Unlock()
+			if trials == 1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if _, err := call(t.Context(), 2); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("got error %v, want %v while the trial call is under way", err, ErrCircuitOpen)
+		}
+		close(release)
+		if err := <-done; err != nil {
+			t.Errorf("trial call: got error %v", err)
+		}
+		if got := b.State(); got != BreakerClosed {
+			t.Errorf("got state %v, want %v", got, BreakerClosed)
+		}
+	})
+
+	t.Run("cancelled calls are not counted", func(t *testing.T) {
+		b := NewBreaker(BreakerPolicy{MinCalls: 1})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		call := CircuitBreaker(b, func(ctx context.Context, _ int) (int, error) { return 0, ctx.Err() })
+		call(ctx, 0)
+		if got := b.State(); got != BreakerClosed {
+			t.Errorf("got state %v, want %v", got, BreakerClosed)
+		}
+	})
+
+	t.Run("MapDeadLetter", func(t *testing.T) {
+		ctx := t.Context()
+		b := NewBreaker(BreakerPolicy{MinCalls: 1, Cooldown: time.Hour})
+		out, dlq := MapDeadLetter(ctx, From(ctx, 1, 2, 3), "call", CircuitBreaker(b, func(context.Context, int) (int, error) {
+			return 0, errDown
+		}))
+		go func() {
+			for range out {
+			}
+		}()
+		var errs []error
+		for d := range dlq {
+			errs = append(errs, d.Err)
+		}
+		want := []error{errDown, ErrCircuitOpen, ErrCircuitOpen}
+		if len(errs) != len(want) {
+			t.Fatalf("got dead letters with errors %v, want %v", errs, want)
+		}
+		for i := range want {
+			if !errors.Is(errs[i], want[i]) {
+				t.Errorf("dead letter %d: got error %v, want %v", i, errs[i], want[i])
+			}
+		}
+	})
+}
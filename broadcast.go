@@ -0,0 +1,179 @@
+package conduit
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// An OverflowPolicy determines what a stage does with a value when a
+// consumer's buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the consumer has room for the value.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the value.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest buffered value to make room.
+	OverflowDropOldest
+	// OverflowDisconnect closes the consumer's channel and stops sending to
+	// it.
+	OverflowDisconnect
+	// OverflowSample keeps a uniform random sample of the values received
+	// while the buffer is full, by replacing a randomly chosen buffered value
+	// or discarding the new one. Stages that cannot replace buffered values,
+	// such as [Broadcast], treat it as OverflowDropNewest.
+	OverflowSample
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropNewest:
+		return "drop newest"
+	case OverflowDropOldest:
+		return "drop oldest"
+	case OverflowDisconnect:
+		return "disconnect"
+	case OverflowSample:
+		return "sample"
+	default:
+		return "OverflowPolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// Broadcast returns n channels that each emit the same values as the input
+// stream. It generalizes [Tee] to any number of outputs.
+//
+// Each output buffers the number of values set with [WithSubscriberBuffer],
+// or failing that, [WithBuffer].
+// By default, each value is sent to every output, in whichever order they
+// have room for it, before the next value is read, so all outputs advance at
+// the pace of the slowest one. [WithOverflow] selects a
+// different [OverflowPolicy] for outputs whose buffer is full, so that a slow
+// consumer does not stall the others; outputs then buffer at least one value.
+func Broadcast[T any](ctx context.Context, stream <-chan T, n uint, opts ...Option) []<-chan T {
+	o := newOptions(opts)
+	size := o.subscriberBuf
+	if size == 0 {
+		size = o.buffer
+	}
+	if o.overflow != OverflowBlock {
+		size = max(size, 1)
+	}
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T, size)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				if out != nil {
+					close(out)
+				}
+			}
+		}()
+		sender := newMultiSender(ctx, outs)
+		for v := range OrDone(ctx, stream) {
+			if o.overflow == OverflowBlock {
+				if !sender.send(v) {
+					return
+				}
+				continue
+			}
+			for i, out := range outs {
+				if out == nil {
+					continue
+				}
+				select {
+				case out <- v:
+					continue
+				default:
+				}
+				switch o.overflow {
+				case OverflowDropOldest:
+					select {
+					case <-out:
+					default:
+					}
+					// Only this goroutine sends, so there is room now.
+					out <- v
+				case OverflowDisconnect:
+					close(out)
+					outs[i] = nil
+				}
+			}
+		}
+	}()
+	return result
+}
+
+// A multiSender sends values to every one of a fixed set of channels, in
+// whichever order they are ready for them, like [Tee] does for two. The
+// number of channels is only known at run time, so it waits on them with
+// reflect.Select, reusing the same select cases for every value. It is not
+// safe for concurrent use.
+type multiSender[T any] struct {
+	outs  []chan T
+	chans []reflect.Value      // chans[i] is outs[i]
+	cases []reflect.SelectCase // cases[0] is ctx.Done(), cases[i+1] sends to outs[i]
+	v     *T                   // the value sent by cases[1:]
+}
+
+func newMultiSender[T any](ctx context.Context, outs []chan T) *multiSender[T] {
+	m := &multiSender[T]{
+		outs:  outs,
+		chans: make([]reflect.Value, len(outs)),
+		cases: make([]reflect.SelectCase, len(outs)+1),
+		v:     new(T),
+	}
+	val := reflect.ValueOf(m.v).Elem()
+	m.cases[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for i, out := range outs {
+		m.chans[i] = reflect.ValueOf(out)
+		m.cases[i+1] = reflect.SelectCase{Dir: reflect.SelectSend, Send: val}
+	}
+	return m
+}
+
+// send sends v to every channel, and reports whether it did so before the
+// context was done.
+func (m *multiSender[T]) send(v T) bool {
+	for i := range m.outs {
+		m.cases[i+1].Chan = m.chans[i]
+	}
+	*m.v = v
+	defer func() {
+		var zero T
+		*m.v = zero
+	}()
+	for {
+		// Send to every channel that is ready without going through
+		// reflect.Select, which is much slower.
+		pending := 0
+		for i, out := range m.outs {
+			if !m.cases[i+1].Chan.IsValid() {
+				continue
+			}
+			select {
+			case out <- v:
+				// A zero Chan disables the case.
+				m.cases[i+1].Chan = reflect.Value{}
+			default:
+				pending++
+			}
+		}
+		if pending == 0 {
+			return true
+		}
+		i, _, _ := reflect.Select(m.cases)
+		if i == 0 {
+			return false
+		}
+		m.cases[i].Chan = reflect.Value{}
+	}
+}
@@ -0,0 +1,112 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBroadcast(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) []<-chan int
+		want  []int
+	}{
+		{
+			name: "Broadcast",
+			setup: func(ctx context.Context) []<-chan int {
+				return Broadcast(ctx, From(ctx, 1, 2, 3), 3)
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "Broadcast buffered",
+			setup: func(ctx context.Context) []<-chan int {
+				return Broadcast(ctx, From(ctx, 1, 2, 3), 2, WithSubscriberBuffer(8))
+			},
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			outs := tt.setup(t.Context())
+			var wg sync.WaitGroup
+			for i, out := range outs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var got []int
+					for v := range out {
+						got = append(got, v)
+					}
+					if !slices.Equal(got, tt.want) {
+						t.Errorf("output %d: got %v, want %v", i, got, tt.want)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			for i, out := range tt.setup(ctx) {
+				for v := range out {
+					t.Errorf("output %d: expected no values after cancellation, got %v", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestBroadcastIndependentOutputs(t *testing.T) {
+	outs := Broadcast(t.Context(), From(t.Context(), 1, 2), 2)
+	// The second output must not wait for the first one to be read.
+	select {
+	case v := <-outs[1]:
+		if v != 1 {
+			t.Errorf("got %d, want 1", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second output blocked on the unread first output")
+	}
+	if got := []int{<-outs[0], <-outs[0], <-outs[1]}; !slices.Equal(got, []int{1, 2, 2}) {
+		t.Errorf("got %v, want [1 2 2]", got)
+	}
+}
+
+func TestBroadcastOverflow(t *testing.T) {
+	tests := []struct {
+		policy   OverflowPolicy
+		wantSlow []int
+	}{
+		{OverflowDropNewest, []int{1, 2}},
+		{OverflowDropOldest, []int{4, 5}},
+		{OverflowDisconnect, []int{1, 2}},
+		{OverflowSample, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			t.Parallel()
+			in := make(chan int)
+			slow := Broadcast(t.Context(), in, 1, WithSubscriberBuffer(2), WithOverflow(tt.policy))[0]
+			for v := range 5 {
+				in <- v + 1
+			}
+			close(in)
+			// Give the stage time to handle the last value before the slow
+			// consumer starts reading.
+			time.Sleep(20 * time.Millisecond)
+			var gotSlow []int
+			for v := range slow {
+				gotSlow = append(gotSlow, v)
+			}
+			if !slices.Equal(gotSlow, tt.wantSlow) {
+				t.Errorf("slow: got %v, want %v", gotSlow, tt.wantSlow)
+			}
+		})
+	}
+}
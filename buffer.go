@@ -0,0 +1,136 @@
+package conduit
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// Buffer returns a channel that emits the values from the input stream,
+// buffering up to n of them, so that the stage feeding it can run ahead of a
+// slow consumer. It is useful for channels from outside the package; the
+// stages of this package accept [WithBuffer] directly.
+// See [BoundedBuffer] for dropping values instead of blocking once the buffer
+// is full.
+func Buffer[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
+	return OrDone(ctx, stream, WithBuffer(n))
+}
+
+// BufferStats reports on the values handled by a [BoundedBuffer] stage. It is
+// safe for concurrent use.
+type BufferStats struct {
+	dropped atomic.Uint64
+}
+
+// Dropped returns the number of values the stage has discarded so far.
+func (s *BufferStats) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// BoundedBuffer returns a channel that emits the values from the input stream,
+// buffering up to size of them. If size is 0, it is treated as 1.
+//
+// By default, the stage stops reading the input stream while the buffer is
+// full, like [Buffer]. [WithOverflow] selects a different [OverflowPolicy], so
+// that a fast producer keeps running and values are discarded instead:
+//   - [OverflowDropNewest] discards the value just received.
+//   - [OverflowDropOldest] discards the oldest buffered value.
+//   - [OverflowSample] keeps a uniform random sample of the values received
+//     while the buffer is full.
+//   - [OverflowDisconnect] discards the buffered values, closes the output
+//     and stops reading the input stream.
+//
+// The returned [BufferStats] counts the discarded values.
+func BoundedBuffer[T any](ctx context.Context, stream <-chan T, size uint, opts ...Option) (<-chan T, *BufferStats) {
+	o := newOptions(opts)
+	out := make(chan T)
+	stats := new(BufferStats)
+	go func() {
+		defer close(out)
+		var (
+			buf = newRing[T](max(size, 1))
+			in  = stream
+			// seen counts the values the buffer has been filled with since it
+			// was last not full, for OverflowSample.
+			seen uint64
+		)
+		for in != nil || buf.len() > 0 {
+			var (
+				send chan<- T
+				head T
+			)
+			if buf.len() > 0 {
+				send, head = out, buf.peek()
+			}
+			recv := in
+			if o.overflow == OverflowBlock && buf.full() {
+				recv = nil
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case send <- head:
+				buf.pop()
+			case v, ok := <-recv:
+				if !ok {
+					in = nil
+					continue
+				}
+				if !buf.full() {
+					buf.push(v)
+					seen = uint64(buf.len())
+					continue
+				}
+				stats.dropped.Add(1)
+				switch o.overflow {
+				case OverflowDropOldest:
+					buf.pop()
+					buf.push(v)
+				case OverflowSample:
+					seen++
+					if i := rand.N(seen); i < uint64(buf.len()) {
+						buf.set(int(i), v)
+					}
+				case OverflowDisconnect:
+					stats.dropped.Add(uint64(buf.len()))
+					return
+				}
+			}
+		}
+	}()
+	return out, stats
+}
+
+// ring is a fixed-capacity FIFO queue.
+type ring[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+func newRing[T any](size uint) *ring[T] {
+	return &ring[T]{buf: make([]T, size)}
+}
+
+func (r *ring[T]) len() int   { return r.n }
+func (r *ring[T]) full() bool { return r.n == len(r.buf) }
+func (r *ring[T]) peek() T    { return r.buf[r.head] }
+
+// push appends v. The ring must not be full.
+func (r *ring[T]) push(v T) {
+	r.buf[(r.head+r.n)%len(r.buf)] = v
+	r.n++
+}
+
+// pop removes the oldest value. The ring must not be empty.
+func (r *ring[T]) pop() {
+	var zero T
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+}
+
+// set replaces the i-th oldest value with v.
+func (r *ring[T]) set(i int, v T) {
+	r.buf[(r.head+i)%len(r.buf)] = v
+}
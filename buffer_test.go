@@ -0,0 +1,170 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBuffer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		runStreamTest(t, func(ctx context.Context) <-chan int {
+			return Buffer(ctx, From(ctx, 1, 2, 3), 2)
+		}, []int{1, 2, 3})
+	})
+
+	t.Run("runs ahead", func(t *testing.T) {
+		in := make(chan int)
+		out := Buffer(t.Context(), in, 3)
+		for v := range 3 {
+			select {
+			case in <- v:
+			case <-time.After(time.Second):
+				t.Fatalf("send %d blocked with an unread buffer", v)
+			}
+		}
+		close(in)
+		var got []int
+		for v := range out {
+			got = append(got, v)
+		}
+		if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestWithBuffer(t *testing.T) {
+	const n = 4
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) []<-chan int
+	}{
+		{
+			name: "FromSeq",
+			setup: func(ctx context.Context) []<-chan int {
+				return []<-chan int{FromSeq(ctx, slices.Values([]int{1}), WithBuffer(n))}
+			},
+		},
+		{
+			name: "FromSlice",
+			setup: func(ctx context.Context) []<-chan int {
+				return []<-chan int{FromSlice(ctx, []int{1}, WithBuffer(n))}
+			},
+		},
+		{
+			name: "FanInSlice",
+			setup: func(ctx context.Context) []<-chan int {
+				return []<-chan int{FanInSlice(ctx, []<-chan int{From(ctx, 1), From(ctx, 2)}, WithBuffer(n))}
+			},
+		},
+		{
+			name: "MergeSortedSlice",
+			setup: func(ctx context.Context) []<-chan int {
+				less := func(a, b int) bool { return a < b }
+				return []<-chan int{MergeSortedSlice(ctx, less, []<-chan int{From(ctx, 1), From(ctx, 2)}, WithBuffer(n))}
+			},
+		},
+		{
+			name: "Map",
+			setup: func(ctx context.Context) []<-chan int {
+				return []<-chan int{Map(ctx, From(ctx, 1), func(_ context.Context, v int) int { return v }, WithBuffer(n))}
+			},
+		},
+		{
+			name: "Tee",
+			setup: func(ctx context.Context) []<-chan int {
+				a, b := Tee(ctx, From(ctx, 1), WithBuffer(n))
+				return []<-chan int{a, b}
+			},
+		},
+		{
+			name: "Route",
+			setup: func(ctx context.Context) []<-chan int {
+				return Route(ctx, From(ctx, 1), 2, func(int) int { return 0 }, WithBuffer(n))
+			},
+		},
+		{
+			name: "Broadcast",
+			setup: func(ctx context.Context) []<-chan int {
+				return Broadcast(ctx, From(ctx, 1), 2, WithBuffer(n))
+			},
+		},
+		{
+			name: "First",
+			setup: func(ctx context.Context) []<-chan int {
+				return []<-chan int{First(ctx, From(ctx, 1), WithBuffer(n))}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, out := range tt.setup(t.Context()) {
+				if got := cap(out); got != n {
+					t.Errorf("output %d: got capacity %d, want %d", i, got, n)
+				}
+				for range out {
+				}
+			}
+		})
+	}
+}
+
+func TestBoundedBuffer(t *testing.T) {
+	tests := []struct {
+		policy      OverflowPolicy
+		sent        int
+		want        []int // nil if any sample of the sent values will do
+		wantLen     int
+		wantDropped uint64
+	}{
+		{policy: OverflowBlock, sent: 6, want: []int{1, 2, 3, 4, 5, 6}, wantLen: 6},
+		{policy: OverflowDropNewest, sent: 6, want: []int{1, 2, 3}, wantLen: 3, wantDropped: 3},
+		{policy: OverflowDropOldest, sent: 6, want: []int{4, 5, 6}, wantLen: 3, wantDropped: 3},
+		{policy: OverflowSample, sent: 100, wantLen: 3, wantDropped: 97},
+		{policy: OverflowDisconnect, sent: 4, want: []int{}, wantDropped: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			t.Parallel()
+			in := make(chan int)
+			out, stats := BoundedBuffer(t.Context(), in, 3, WithOverflow(tt.policy))
+			go func() {
+				defer close(in)
+				for v := range tt.sent {
+					in <- v + 1
+				}
+			}()
+			if tt.policy != OverflowBlock {
+				// Let the producer overrun the buffer before reading.
+				time.Sleep(20 * time.Millisecond)
+			}
+			got := []int{}
+			for v := range out {
+				got = append(got, v)
+			}
+			if tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("got %d values, want %d", len(got), tt.wantLen)
+			}
+			for _, v := range got {
+				if v < 1 || v > tt.sent {
+					t.Errorf("got value %d that was never sent", v)
+				}
+			}
+			if d := stats.Dropped(); d != tt.wantDropped {
+				t.Errorf("got %d dropped, want %d", d, tt.wantDropped)
+			}
+		})
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		runCancelledStreamTest(t, func(ctx context.Context) <-chan int {
+			out, _ := BoundedBuffer(ctx, make(chan int), 3)
+			return out
+		})
+	})
+}
@@ -7,13 +7,31 @@
 // context-based cancellation throughout.
 //
 // Features include:
-//   - Creating streams from values, sequences, or generators ([From],
-//     [FromSeq], [FromSeq2], [Repeat])
-//   - Transforming and filtering streams ([Map], [Skip], [SkipN], [Take],
-//     [First])
-//   - Combining and splitting streams ([FanIn], [FanOut], [Tee], [Bridge],
-//     [ChanChan])
-//   - Safe consumption ([OrDone])
+//   - Creating streams from values, sequences, readers, or generators
+//     ([From], [FromSlice], [FromSeq], [FromSeq2], [FromReader], [Repeat])
+//   - Transforming and filtering streams ([Map], [TryMap], [Batch], [Skip],
+//     [SkipN], [Take], [First])
+//   - Combining streams ([FanIn], [FanInSlice], [Bridge], [Zip], [ZipWith],
+//     [CombineLatest], [WithLatestFrom], [MergeSorted], [MergeSortedSlice])
+//   - Splitting streams ([FanOut], [ChanChan], [Tee], [Broadcast], [GroupBy],
+//     [Partition], [Route])
+//   - Aggregating streams ([Reduce], [Fold], [Scan])
+//   - Grouping streams into time windows ([TumblingWindow], [SlidingWindow],
+//     [EventTimeWindow], [SessionWindow])
+//   - Processing streams concurrently ([ParallelMap], [WorkerPool],
+//     [ShardBy])
+//   - Pacing streams ([RateLimit], [RateLimitWith], [Limiter], [Debounce],
+//     [ThrottleFirst], [ThrottleLast], [Sample])
+//   - Safe consumption ([OrDone]) and writing streams out ([ToWriter])
+//   - Tuning backpressure with buffered outputs, optionally dropping values
+//     when full ([WithBuffer], [Buffer], [BoundedBuffer])
+//   - Error propagation, cancellation, and panic recovery across stages
+//     ([Group], [WithGroup])
+//   - Retrying fallible stage functions ([Retry], [RetryPolicy])
+//   - Bounding the time spent on each value ([Timeout], [TimeoutOr])
+//   - Failing fast when a downstream service is down ([CircuitBreaker],
+//     [Breaker])
+//   - Routing failed values to a dead-letter channel ([MapDeadLetter])
 //
 // All functions are context-aware and designed to prevent goroutine leaks.
 package conduit
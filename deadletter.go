@@ -0,0 +1,51 @@
+package conduit
+
+import (
+	"context"
+)
+
+// A DeadLetter records a value that a stage failed to process.
+type DeadLetter[T any] struct {
+	// Stage is the name of the stage that failed.
+	Stage string
+	// Value is the value the stage failed to process. It can be sent through
+	// the stage again to replay it.
+	Value T
+	// Err is the error returned by the stage function, or a *[PanicError] if
+	// it panicked.
+	Err error
+}
+
+// MapDeadLetter returns a channel that emits the results of applying fn to
+// each value from the input stream, and a channel of dead letters. When fn
+// returns an error or panics, the value is sent to the dead-letter channel,
+// tagged with the stage name, and the stage moves on to the next value.
+// See [TryMap] for stopping at the first error instead.
+//
+// Both channels must be consumed.
+func MapDeadLetter[T, U any](ctx context.Context, stream <-chan T, stage string, fn func(context.Context, T) (U, error), opts ...Option) (_ <-chan U, deadLetters <-chan DeadLetter[T]) {
+	o := newOptions(opts)
+	out := make(chan U, o.buffer)
+	dlq := make(chan DeadLetter[T], o.buffer)
+	go func() {
+		defer close(out)
+		defer close(dlq)
+		for v := range stream {
+			val, err := tryCall(ctx, fn, v)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case dlq <- DeadLetter[T]{Stage: stage, Value: v, Err: err}:
+				}
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- val:
+			}
+		}
+	}()
+	return out, dlq
+}
@@ -0,0 +1,76 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestMapDeadLetter(t *testing.T) {
+	errOdd := errors.New("odd")
+	fn := func(_ context.Context, v int) (int, error) {
+		switch {
+		case v == 4:
+			panic("four")
+		case v%2 != 0:
+			return 0, errOdd
+		}
+		return v * 10, nil
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		ctx := t.Context()
+		out, dlq := MapDeadLetter(ctx, From(ctx, 1, 2, 3, 4, 6), "parse", fn)
+		var (
+			wg      sync.WaitGroup
+			letters []DeadLetter[int]
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range dlq {
+				letters = append(letters, d)
+			}
+		}()
+		var got []int
+		for v := range out {
+			got = append(got, v)
+		}
+		wg.Wait()
+		checkStream(t, got, []int{20, 60})
+
+		values := make([]int, len(letters))
+		for i, d := range letters {
+			values[i] = d.Value
+			if d.Stage != "parse" {
+				t.Errorf("dead letter %d: got stage %q, want %q", i, d.Stage, "parse")
+			}
+		}
+		if want := []int{1, 3, 4}; !slices.Equal(values, want) {
+			t.Fatalf("got dead letters for %v, want %v", values, want)
+		}
+		if !errors.Is(letters[0].Err, errOdd) {
+			t.Errorf("got error %v, want %v", letters[0].Err, errOdd)
+		}
+		var perr *PanicError
+		if !errors.As(letters[2].Err, &perr) || perr.Value != "four" {
+			t.Errorf("got error %v, want a *PanicError for %q", letters[2].Err, "four")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		out, dlq := MapDeadLetter(ctx, From(ctx, 1, 2, 3), "parse", fn)
+		for v := range out {
+			t.Errorf("expected no values after cancellation, got %v", v)
+		}
+		for d := range dlq {
+			t.Errorf("expected no dead letters after cancellation, got %v", d)
+		}
+	})
+}
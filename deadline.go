@@ -0,0 +1,88 @@
+package conduit
+
+import (
+	"container/heap"
+	"time"
+)
+
+// deadlines is a min-heap of per-key deadlines. It lets a single goroutine
+// drive any number of per-key timers with one [time.Timer].
+type deadlines[K comparable] struct {
+	items []*deadline[K]
+	index map[K]*deadline[K]
+}
+
+type deadline[K comparable] struct {
+	key K
+	at  time.Time
+	pos int
+}
+
+func newDeadlines[K comparable]() *deadlines[K] {
+	return &deadlines[K]{index: make(map[K]*deadline[K])}
+}
+
+// set adds or moves the deadline for key.
+func (d *deadlines[K]) set(key K, at time.Time) {
+	if item, ok := d.index[key]; ok {
+		item.at = at
+		heap.Fix(d, item.pos)
+		return
+	}
+	item := &deadline[K]{key: key, at: at}
+	d.index[key] = item
+	heap.Push(d, item)
+}
+
+// remove removes the deadline for key, if any.
+func (d *deadlines[K]) remove(key K) {
+	if item, ok := d.index[key]; ok {
+		heap.Remove(d, item.pos)
+	}
+}
+
+// peek returns the key with the earliest deadline.
+func (d *deadlines[K]) peek() (K, time.Time, bool) {
+	if len(d.items) == 0 {
+		var zero K
+		return zero, time.Time{}, false
+	}
+	return d.items[0].key, d.items[0].at, true
+}
+
+// reset arms timer for the earliest deadline, and returns its channel, or nil
+// if there are no deadlines.
+func (d *deadlines[K]) reset(timer *time.Timer) <-chan time.Time {
+	_, at, ok := d.peek()
+	if !ok {
+		timer.Stop()
+		return nil
+	}
+	timer.Reset(time.Until(at))
+	return timer.C
+}
+
+// heap.Interface implementation.
+
+func (d *deadlines[K]) Len() int           { return len(d.items) }
+func (d *deadlines[K]) Less(i, j int) bool { return d.items[i].at.Before(d.items[j].at) }
+func (d *deadlines[K]) Swap(i, j int) {
+	d.items[i], d.items[j] = d.items[j], d.items[i]
+	d.items[i].pos = i
+	d.items[j].pos = j
+}
+
+func (d *deadlines[K]) Push(x any) {
+	item := x.(*deadline[K])
+	item.pos = len(d.items)
+	d.items = append(d.items, item)
+}
+
+func (d *deadlines[K]) Pop() any {
+	n := len(d.items)
+	item := d.items[n-1]
+	d.items[n-1] = nil
+	d.items = d.items[:n-1]
+	delete(d.index, item.key)
+	return item
+}
@@ -0,0 +1,33 @@
+package conduit
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDeadlines(t *testing.T) {
+	base := time.Now()
+	d := newDeadlines[string]()
+	if _, _, ok := d.peek(); ok {
+		t.Fatal("expected no deadlines")
+	}
+	d.set("a", base.Add(3*time.Second))
+	d.set("b", base.Add(1*time.Second))
+	d.set("c", base.Add(2*time.Second))
+	d.set("b", base.Add(4*time.Second)) // move b to the back
+	d.remove("c")
+	d.remove("missing")
+
+	var got []string
+	for key, _, ok := d.peek(); ok; key, _, ok = d.peek() {
+		got = append(got, key)
+		d.remove(key)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(d.index) != 0 {
+		t.Errorf("index not cleaned up: %v", d.index)
+	}
+}
@@ -2,9 +2,13 @@ package conduit_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand/v2"
+	"os"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +42,60 @@ func ExampleSkip() {
 	// 5
 }
 
+func ExamplePartition() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records := conduit.From(ctx, "42", "x", "7", "")
+	valid, invalid := conduit.Partition(ctx, records, func(_ context.Context, s string) bool {
+		_, err := strconv.Atoi(s)
+		return err == nil
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for r := range invalid {
+			fmt.Printf("quarantine: %q\n", r)
+		}
+	}()
+	for r := range valid {
+		fmt.Println("valid:", r)
+	}
+	wg.Wait()
+	// Unordered output:
+	// valid: 42
+	// valid: 7
+	// quarantine: "x"
+	// quarantine: ""
+}
+
+func ExampleRoute() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	levels := []string{"debug", "info", "error"}
+	logs := conduit.From(ctx, "info: started", "error: disk full", "debug: tick", "info: stopped")
+	outs := conduit.Route(ctx, logs, uint(len(levels)), func(line string) int {
+		level, _, _ := strings.Cut(line, ":")
+		return slices.Index(levels, level)
+	})
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for line := range out {
+				fmt.Printf("%s sink <- %q\n", levels[i], line)
+			}
+		}()
+	}
+	wg.Wait()
+	// Unordered output:
+	// info sink <- "info: started"
+	// error sink <- "error: disk full"
+	// debug sink <- "debug: tick"
+	// info sink <- "info: stopped"
+}
+
 func ExampleSkipN() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -82,6 +140,570 @@ func ExampleMap() {
 	// num:3
 }
 
+func ExampleTryMap() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, "1", "2", "x", "4")
+	out, g := conduit.TryMap(ctx, stream, func(_ context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	for v := range out {
+		fmt.Println(v)
+	}
+	if err := g.Wait(); err != nil {
+		fmt.Println("error:", err)
+	}
+	// Output:
+	// 1
+	// 2
+	// error: strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleBatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3, 4, 5)
+	for batch := range conduit.Batch(ctx, stream, 2, time.Second) {
+		fmt.Println(batch)
+	}
+	// Output:
+	// [1 2]
+	// [3 4]
+	// [5]
+}
+
+func ExampleTumblingWindow() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3)
+	// The input closes before the first minute ends, so the partial window
+	// is flushed.
+	for window := range conduit.TumblingWindow(ctx, stream, time.Minute) {
+		fmt.Println(window)
+	}
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleSlidingWindow() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan string)
+	go func() {
+		defer close(events)
+		time.Sleep(50 * time.Millisecond)
+		events <- "a"
+		time.Sleep(100 * time.Millisecond)
+		events <- "b"
+		time.Sleep(250 * time.Millisecond)
+	}()
+	// Windows span 200ms and start every 100ms.
+	for window := range conduit.SlidingWindow(ctx, events, 200*time.Millisecond, 100*time.Millisecond) {
+		fmt.Println(window)
+	}
+	// Output:
+	// [a]
+	// [a b]
+	// [b]
+}
+
+func ExampleEventTimeWindow() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	type reading struct {
+		sensor string
+		at     time.Time
+	}
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	stream := conduit.From(ctx,
+		reading{"a", base.Add(10 * time.Second)},
+		reading{"b", base.Add(70 * time.Second)},
+		reading{"c", base.Add(50 * time.Second)}, // out of order, within bounds
+		reading{"d", base.Add(130 * time.Second)},
+		reading{"e", base.Add(20 * time.Second)}, // too late
+	)
+	windows, late := conduit.EventTimeWindow(ctx, stream, time.Minute,
+		func(r reading) time.Time { return r.at },
+		conduit.WithOutOfOrderness(15*time.Second),
+	)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for r := range late {
+			fmt.Println("late:", r.sensor)
+		}
+	}()
+	for w := range windows {
+		var sensors []string
+		for _, r := range w.Values {
+			sensors = append(sensors, r.sensor)
+		}
+		fmt.Println(w.Start.Format(time.TimeOnly), sensors)
+	}
+	wg.Wait()
+	// Unordered output:
+	// 12:00:00 [a c]
+	// late: e
+	// 12:01:00 [b]
+	// 12:02:00 [d]
+}
+
+func ExampleSessionWindow() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	type click struct {
+		user string
+		page string
+	}
+	stream := conduit.From(ctx,
+		click{"alice", "/home"},
+		click{"bob", "/home"},
+		click{"alice", "/cart"},
+	)
+	sessions := conduit.SessionWindow(ctx, stream, func(c click) string { return c.user }, 30*time.Minute)
+	for session := range sessions {
+		fmt.Println(session)
+	}
+	// Unordered output:
+	// [{alice /home} {alice /cart}]
+	// [{bob /home}]
+}
+
+func ExampleParallelMap() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 30, 20, 10)
+	out := conduit.ParallelMap(ctx, stream, 3, func(_ context.Context, ms int) string {
+		time.Sleep(time.Duration(ms) * time.Millisecond)
+		return fmt.Sprintf("slept %dms", ms)
+	}, conduit.WithReorderBuffer(3))
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Output:
+	// slept 30ms
+	// slept 20ms
+	// slept 10ms
+}
+
+func ExampleWorkerPool() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3, 4)
+	out, pool := conduit.WorkerPool(ctx, stream, 1, func(_ context.Context, v int) int {
+		return v * v
+	})
+	// Scale up when load increases.
+	pool.Resize(4)
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Unordered output:
+	// 1
+	// 4
+	// 9
+	// 16
+}
+
+func ExampleShardBy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	type deposit struct {
+		account string
+		amount  int
+	}
+	stream := conduit.From(ctx,
+		deposit{"alice", 10},
+		deposit{"bob", 5},
+		deposit{"alice", 20},
+		deposit{"bob", 7},
+	)
+	// Deposits for the same account are applied in order by the same worker.
+	balances := make(map[string]int)
+	var mu sync.Mutex
+	out := conduit.ShardBy(ctx, stream, 2, func(d deposit) string { return d.account },
+		func(_ context.Context, d deposit) string {
+			mu.Lock()
+			defer mu.Unlock()
+			balances[d.account] += d.amount
+			return fmt.Sprintf("%s: %d", d.account, balances[d.account])
+		})
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Unordered output:
+	// alice: 10
+	// alice: 30
+	// bob: 5
+	// bob: 12
+}
+
+func ExampleWithGroup_recover() {
+	g, ctx := conduit.WithGroup(context.Background())
+	stream := conduit.From(ctx, 1, 2, 0, 4)
+	out := conduit.Map(ctx, stream, func(_ context.Context, v int) int {
+		return 12 / v // panics on 0
+	})
+	for v := range out {
+		fmt.Println(v)
+	}
+	var perr *conduit.PanicError
+	if err := g.Wait(); errors.As(err, &perr) {
+		fmt.Println("recovered:", perr.Value)
+	}
+	// Output:
+	// 12
+	// 6
+	// recovered: runtime error: integer divide by zero
+}
+
+func ExampleRetry() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	attempts := 0
+	fetch := func(_ context.Context, id int) (string, error) {
+		attempts++
+		if attempts%3 != 0 {
+			return "", errors.New("service unavailable")
+		}
+		return fmt.Sprintf("item %d", id), nil
+	}
+	policy := conduit.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     conduit.ExponentialBackoff(time.Millisecond, 10*time.Millisecond),
+	}
+	out, g := conduit.TryMap(ctx, conduit.From(ctx, 1, 2), conduit.Retry(policy, fetch))
+	for v := range out {
+		fmt.Println(v)
+	}
+	fmt.Println("error:", g.Wait(), "attempts:", attempts)
+	// Output:
+	// item 1
+	// item 2
+	// error: <nil> attempts: 6
+}
+
+func ExampleTimeoutOr() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lookup := func(ctx context.Context, ms int) string {
+		select {
+		case <-ctx.Done():
+			return ""
+		case <-time.After(time.Duration(ms) * time.Millisecond):
+			return fmt.Sprintf("took %dms", ms)
+		}
+	}
+	cached := func(context.Context, int) string { return "cached" }
+	out := conduit.Map(ctx, conduit.From(ctx, 1, 500, 2), conduit.TimeoutOr(50*time.Millisecond, lookup, cached))
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Output:
+	// took 1ms
+	// cached
+	// took 2ms
+}
+
+func ExampleBuffer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Let the producer run up to 8 values ahead of the consumer.
+	stream := conduit.Buffer(ctx, conduit.From(ctx, 1, 2, 3), 8)
+	squares := conduit.Map(ctx, stream, func(_ context.Context, v int) int {
+		return v * v
+	}, conduit.WithBuffer(8))
+	for v := range squares {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 4
+	// 9
+}
+
+func ExampleBoundedBuffer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	readings := conduit.FromSeq(ctx, func(yield func(int) bool) {
+		for i := 1; i <= 100 && yield(i); i++ {
+		}
+	})
+	// Let the producer run at full speed, keeping only the latest readings
+	// for a slow consumer.
+	latest, stats := conduit.BoundedBuffer(ctx, readings, 3, conduit.WithOverflow(conduit.OverflowDropOldest))
+	time.Sleep(50 * time.Millisecond)
+	for v := range latest {
+		fmt.Println(v)
+	}
+	fmt.Println("dropped:", stats.Dropped())
+	// Output:
+	// 98
+	// 99
+	// 100
+	// dropped: 97
+}
+
+func ExampleCircuitBreaker() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	breaker := conduit.NewBreaker(conduit.BreakerPolicy{MinCalls: 2, Cooldown: time.Minute})
+	calls := 0
+	lookup := func(context.Context, int) (string, error) {
+		calls++
+		return "", errors.New("service unavailable")
+	}
+	_, rejected := conduit.MapDeadLetter(ctx, conduit.From(ctx, 1, 2, 3, 4, 5), "lookup", conduit.CircuitBreaker(breaker, lookup))
+	for d := range rejected {
+		fmt.Println(d.Value, d.Err)
+	}
+	fmt.Println("calls:", calls, "state:", breaker.State())
+	// Output:
+	// 1 service unavailable
+	// 2 service unavailable
+	// 3 conduit: circuit breaker is open
+	// 4 conduit: circuit breaker is open
+	// 5 conduit: circuit breaker is open
+	// calls: 2 state: open
+}
+
+func ExampleFromReader() {
+	g, ctx := conduit.WithGroup(context.Background())
+	input := strings.NewReader("alpha\nbeta\ngamma\n")
+	lines, _ := conduit.FromReader(ctx, input, nil)
+	upper := conduit.Map(ctx, lines, func(_ context.Context, s string) string {
+		return strings.ToUpper(s)
+	})
+	err := conduit.ToWriter(ctx, os.Stdout, upper, func(s string) ([]byte, error) {
+		return []byte(s + "\n"), nil
+	})
+	fmt.Println("error:", errors.Join(err, g.Wait()))
+	// Output:
+	// ALPHA
+	// BETA
+	// GAMMA
+	// error: <nil>
+}
+
+func ExampleMapDeadLetter() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rows := conduit.From(ctx, "1", "two", "3")
+	out, deadLetters := conduit.MapDeadLetter(ctx, rows, "parse", func(_ context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	var (
+		wg     sync.WaitGroup
+		failed []conduit.DeadLetter[string]
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for d := range deadLetters {
+			failed = append(failed, d)
+		}
+	}()
+	for v := range out {
+		fmt.Println(v)
+	}
+	wg.Wait()
+	for _, d := range failed {
+		fmt.Printf("%s failed on %q: %v\n", d.Stage, d.Value, d.Err)
+	}
+	// Output:
+	// 1
+	// 3
+	// parse failed on "two": strconv.Atoi: parsing "two": invalid syntax
+}
+
+func ExampleRateLimit() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3, 4)
+	start := time.Now()
+	// 2 values pass immediately, the rest at 20 per second.
+	for v := range conduit.RateLimit(ctx, stream, 20, 2) {
+		fmt.Println(v)
+	}
+	fmt.Println(time.Since(start) >= 90*time.Millisecond)
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 4
+	// true
+}
+
+func ExampleRateLimitWith() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Both branches share the same quota.
+	quota := conduit.NewLimiter(1000, 10)
+	streams := conduit.FanOut(ctx, 2, func(ctx context.Context, i uint) <-chan string {
+		batches := conduit.From(ctx, fmt.Sprintf("batch %d", i))
+		return conduit.RateLimitWith(ctx, batches, quota, func(string) uint { return 5 })
+	})
+	for v := range conduit.FanIn(ctx, streams...) {
+		fmt.Println(v)
+	}
+	// Unordered output:
+	// batch 0
+	// batch 1
+}
+
+func ExampleDebounce() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan string)
+	go func() {
+		defer close(changes)
+		// A burst of writes to the same file, then a pause.
+		changes <- "main.go (write 1)"
+		changes <- "main.go (write 2)"
+		changes <- "main.go (write 3)"
+		time.Sleep(100 * time.Millisecond)
+		changes <- "go.mod"
+	}()
+	for v := range conduit.Debounce(ctx, changes, 50*time.Millisecond) {
+		fmt.Println("rebuild after", v)
+	}
+	// Output:
+	// rebuild after main.go (write 3)
+	// rebuild after go.mod
+}
+
+func ExampleThrottleFirst() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clicks := conduit.From(ctx, "click 1", "click 2", "click 3")
+	// Ignore double clicks.
+	for v := range conduit.ThrottleFirst(ctx, clicks, time.Second) {
+		fmt.Println(v)
+	}
+	// Output: click 1
+}
+
+func ExampleThrottleLast() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	positions := conduit.From(ctx, 10, 20, 30)
+	// The input closes before the interval ends, so the latest value is
+	// flushed.
+	for v := range conduit.ThrottleLast(ctx, positions, time.Second) {
+		fmt.Println(v)
+	}
+	// Output: 30
+}
+
+func ExampleSample() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	readings := make(chan int)
+	ticks := make(chan time.Time)
+	go func() {
+		defer close(readings)
+		readings <- 1
+		readings <- 2
+		ticks <- time.Now()
+		readings <- 3
+		readings <- 4
+		ticks <- time.Now()
+	}()
+	for v := range conduit.Sample(ctx, readings, ticks) {
+		fmt.Println(v)
+	}
+	// Output:
+	// 2
+	// 4
+}
+
+func ExampleWithGroup() {
+	g, ctx := conduit.WithGroup(context.Background())
+	numbers := conduit.Repeat(ctx, func(context.Context) int { return 1 })
+	out, _ := conduit.TryMap(ctx, numbers, func(_ context.Context, v int) (int, error) {
+		return 0, errors.New("lookup failed")
+	})
+	for v := range out {
+		fmt.Println(v)
+	}
+	// The error canceled ctx, which also stopped the Repeat stage.
+	fmt.Println(g.Wait())
+	// Output:
+	// lookup failed
+}
+
+func ExampleReduce() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 3, 1, 4, 1, 5)
+	out := conduit.Reduce(ctx, stream, func(_ context.Context, acc, v int) int {
+		return max(acc, v)
+	})
+	if v, ok := <-out; ok {
+		fmt.Println(v)
+	}
+	// Output: 5
+}
+
+func ExampleFold() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, "a", "b", "c")
+	out := conduit.Fold(ctx, stream, 0, func(_ context.Context, n int, _ string) int {
+		return n + 1
+	})
+	fmt.Println(<-out)
+	// Output: 3
+}
+
+func ExampleScan() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3, 4)
+	out := conduit.Scan(ctx, stream, 0, func(_ context.Context, sum, v int) int {
+		return sum + v
+	})
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 3
+	// 6
+	// 10
+}
+
+func ExampleGroupBy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	type event struct {
+		tenant string
+		id     int
+	}
+	stream := conduit.From(ctx,
+		event{"acme", 1},
+		event{"globex", 2},
+		event{"acme", 3},
+	)
+	groups := conduit.GroupBy(ctx, stream, func(e event) string { return e.tenant })
+	var wg sync.WaitGroup
+	for g := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range g.Stream {
+				fmt.Printf("%s: %d\n", g.Key, e.id)
+			}
+		}()
+	}
+	wg.Wait()
+	// Unordered output:
+	// acme: 1
+	// globex: 2
+	// acme: 3
+}
+
 func ExampleFanIn() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -122,6 +744,103 @@ func ExampleBridge() {
 	// 3
 }
 
+func ExampleZip() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	requests := conduit.From(ctx, "GET /a", "GET /b", "GET /c")
+	ids := conduit.From(ctx, 101, 102)
+	for p := range conduit.Zip(ctx, requests, ids) {
+		fmt.Println(p.Second, p.First)
+	}
+	// Output:
+	// 101 GET /a
+	// 102 GET /b
+}
+
+func ExampleZipWith() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	prices := conduit.From(ctx, 2, 3, 5)
+	quantities := conduit.From(ctx, 10, 20, 30)
+	totals := conduit.ZipWith(ctx, prices, quantities, func(_ context.Context, p, q int) int {
+		return p * q
+	})
+	for v := range totals {
+		fmt.Println(v)
+	}
+	// Output:
+	// 20
+	// 60
+	// 150
+}
+
+func ExampleCombineLatest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	temperature := make(chan int)
+	unit := make(chan string)
+	go func() {
+		defer close(temperature)
+		defer close(unit)
+		unit <- "C"
+		temperature <- 21
+		temperature <- 22
+		unit <- "F"
+	}()
+	out := conduit.CombineLatest(ctx, temperature, unit, func(_ context.Context, t int, u string) string {
+		if u == "F" {
+			t = t*9/5 + 32
+		}
+		return fmt.Sprintf("%d°%s", t, u)
+	})
+	for v := range out {
+		fmt.Println(v)
+	}
+	// Output:
+	// 21°C
+	// 22°C
+	// 71°F
+}
+
+func ExampleWithLatestFrom() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records := make(chan string)
+	config := make(chan int)
+	go func() {
+		defer close(records)
+		defer close(config)
+		config <- 1
+		records <- "a"
+		records <- "b"
+		config <- 2
+		records <- "c"
+	}()
+	for p := range conduit.WithLatestFrom(ctx, records, config) {
+		fmt.Printf("%s (config v%d)\n", p.First, p.Second)
+	}
+	// Output:
+	// a (config v1)
+	// b (config v1)
+	// c (config v2)
+}
+
+func ExampleMergeSorted() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shard1 := conduit.From(ctx, "2025-01-01 boot", "2025-01-03 login")
+	shard2 := conduit.From(ctx, "2025-01-02 update", "2025-01-04 logout")
+	less := func(a, b string) bool { return a < b }
+	for line := range conduit.MergeSorted(ctx, less, shard1, shard2) {
+		fmt.Println(line)
+	}
+	// Output:
+	// 2025-01-01 boot
+	// 2025-01-02 update
+	// 2025-01-03 login
+	// 2025-01-04 logout
+}
+
 func ExampleOrDone() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -166,6 +885,38 @@ func ExampleTee() {
 	// out2: 3
 }
 
+func ExampleBroadcast() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := conduit.From(ctx, 1, 2, 3)
+	sinks := []string{"audit", "metrics", "storage"}
+	outs := conduit.Broadcast(ctx, stream, uint(len(sinks)),
+		conduit.WithSubscriberBuffer(16),
+		conduit.WithOverflow(conduit.OverflowDropOldest),
+	)
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range out {
+				fmt.Printf("%s: %d\n", sinks[i], v)
+			}
+		}()
+	}
+	wg.Wait()
+	// Unordered output:
+	// audit: 1
+	// audit: 2
+	// audit: 3
+	// metrics: 1
+	// metrics: 2
+	// metrics: 3
+	// storage: 1
+	// storage: 2
+	// storage: 3
+}
+
 func ExampleFrom() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
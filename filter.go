@@ -7,8 +7,9 @@ import (
 // First returns a channel that emits the first value from the input stream.
 // If the stream is empty or the context is canceled, the channel will be closed
 // without emitting any values.
-func First[T any](ctx context.Context, stream <-chan T) <-chan T {
-	out := make(chan T, 1)
+func First[T any](ctx context.Context, stream <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, max(o.buffer, 1))
 	go func() {
 		defer close(out)
 		select {
@@ -27,13 +28,15 @@ func First[T any](ctx context.Context, stream <-chan T) <-chan T {
 // Skip returns a channel that emits values from the input stream for which
 // eq(ctx, v) returns false. If eq is nil, the input stream is returned
 // unchanged.
-func Skip[T any](ctx context.Context, stream <-chan T, eq func(context.Context, T) bool) <-chan T {
+func Skip[T any](ctx context.Context, stream <-chan T, eq func(context.Context, T) bool, opts ...Option) <-chan T {
 	if eq == nil {
 		return stream
 	}
-	out := make(chan T)
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
+		defer recoverStage(ctx)
 		for v := range stream {
 			if eq(ctx, v) {
 				continue
@@ -50,11 +53,12 @@ func Skip[T any](ctx context.Context, stream <-chan T, eq func(context.Context,
 
 // SkipN returns a channel that skips the first n values from the input stream,
 // then emits the rest. If n is 0, the input stream is returned unchanged.
-func SkipN[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
+func SkipN[T any](ctx context.Context, stream <-chan T, n uint, opts ...Option) <-chan T {
 	if n == 0 {
 		return stream
 	}
-	out := make(chan T)
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
 		for range n {
@@ -80,7 +84,7 @@ func SkipN[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
 
 // Take returns a channel that emits the first n values from the input stream,
 // or fewer if the stream closes or the context is canceled.
-func Take[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
+func Take[T any](ctx context.Context, stream <-chan T, n uint, opts ...Option) <-chan T {
 	switch n {
 	case 0:
 		// Similar convention to calling time.After(0) which returns a closed channel.
@@ -88,9 +92,10 @@ func Take[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
 		close(out)
 		return out
 	case 1:
-		return First(ctx, stream)
+		return First(ctx, stream, opts...)
 	}
-	out := make(chan T)
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
 		for range n {
@@ -107,3 +112,51 @@ func Take[T any](ctx context.Context, stream <-chan T, n uint) <-chan T {
 	}()
 	return out
 }
+
+// Partition returns two channels: one that emits the values from the input
+// stream for which pred(ctx, v) returns true, and one that emits the rest.
+// Both channels must be consumed.
+// See [Skip] for discarding values instead.
+func Partition[T any](ctx context.Context, stream <-chan T, pred func(context.Context, T) bool, opts ...Option) (matched, rest <-chan T) {
+	outs := Route(ctx, stream, 2, func(v T) int {
+		if pred(ctx, v) {
+			return 0
+		}
+		return 1
+	}, opts...)
+	return outs[0], outs[1]
+}
+
+// Route returns n channels, and sends each value from the input stream to the
+// channel at the index returned by selector(v). Values for which selector
+// returns an index outside [0, n) are discarded. All channels must be
+// consumed.
+func Route[T any](ctx context.Context, stream <-chan T, n uint, selector func(T) int, opts ...Option) []<-chan T {
+	o := newOptions(opts)
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T, o.buffer)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		defer recoverStage(ctx)
+		for v := range OrDone(ctx, stream) {
+			i := selector(v)
+			if i < 0 || i >= len(outs) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case outs[i] <- v:
+			}
+		}
+	}()
+	return result
+}
@@ -2,6 +2,8 @@ package conduit
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"testing"
 )
 
@@ -83,3 +85,65 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) []<-chan int
+		want  [][]int
+	}{
+		{
+			name: "Partition",
+			setup: func(ctx context.Context) []<-chan int {
+				even, odd := Partition(ctx, From(ctx, 1, 2, 3, 4, 5), func(_ context.Context, v int) bool {
+					return v%2 == 0
+				})
+				return []<-chan int{even, odd}
+			},
+			want: [][]int{{2, 4}, {1, 3, 5}},
+		},
+		{
+			name: "Route",
+			setup: func(ctx context.Context) []<-chan int {
+				return Route(ctx, From(ctx, 1, 2, 3, 4, 5, 6, 7), 3, func(v int) int {
+					if v == 7 {
+						return -1 // discarded
+					}
+					return v % 3
+				})
+			},
+			want: [][]int{{3, 6}, {1, 4}, {2, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			outs := tt.setup(t.Context())
+			got := make([][]int, len(outs))
+			var wg sync.WaitGroup
+			for i, out := range outs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for v := range out {
+						got[i] = append(got[i], v)
+					}
+				}()
+			}
+			wg.Wait()
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			for i, out := range tt.setup(ctx) {
+				for v := range out {
+					t.Errorf("output %d: expected no values after cancellation, got %v", i, v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package conduit
+
+import (
+	"context"
+	"sync"
+)
+
+// A Group is a handle to the stages of a pipeline that can fail. It is
+// modeled on errgroup.Group: the first error reported by a stage cancels the
+// group's context, and [Group.Wait] returns that error.
+//
+// A Group is created with [WithGroup], or implicitly by a fallible stage such
+// as [TryMap] when its context does not already carry one.
+//
+// Stages built with a group's context also recover panics raised by the
+// functions passed to them, such as the fn of [Map] or [Repeat]. A stage that
+// recovers a panic closes its outputs and reports a *[PanicError] to the
+// group. Without a group, such a panic crashes the program.
+//
+// Only the fallible stages, [TryMap] and [FromReader], are waited for by
+// [Group.Wait]. Consume the outputs of the pipeline until they close before
+// calling Wait, so that every stage has finished, and any error or panic it
+// ran into has been reported, by the time Wait returns.
+type Group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	err    error
+}
+
+type groupKey struct{}
+
+// WithGroup returns a new Group and a context derived from ctx. Every stage
+// built with the derived context is owned by the group: fallible stages report
+// their errors to it, and all of them stop once the first error cancels the
+// context.
+func WithGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{cancel: cancel}
+	return g, context.WithValue(ctx, groupKey{}, g)
+}
+
+// groupFor returns the Group carried by ctx, or a new Group if there is none.
+func groupFor(ctx context.Context) (*Group, context.Context) {
+	if g, ok := ctx.Value(groupKey{}).(*Group); ok {
+		return g, ctx
+	}
+	return WithGroup(ctx)
+}
+
+// Wait blocks until the fallible stages in the group, [TryMap] and
+// [FromReader], have returned, then returns the first non-nil error (if any)
+// reported by any stage in the group, including a *[PanicError] reported by a
+// stage that recovered a panic. Other stages are not waited for, so Wait
+// should be called once the outputs of the pipeline have been consumed; the
+// group's context is canceled once Wait returns, which stops any stage that
+// is still running.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cancel(g.err)
+	return g.err
+}
+
+// fail records err as the group's error if it is the first one, and cancels
+// the group's context.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.err == nil {
+		g.err = err
+		g.cancel(err)
+	}
+}
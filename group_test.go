@@ -0,0 +1,66 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	errBoom := errors.New("boom")
+	t.Run("cancels owned stages", func(t *testing.T) {
+		t.Parallel()
+		g, ctx := WithGroup(t.Context())
+		numbers := Repeat(ctx, func(context.Context) int { return 1 })
+		stream, got := TryMap(ctx, numbers, func(ctx context.Context, v int) (int, error) {
+			return 0, errBoom
+		})
+		if got != g {
+			t.Fatal("TryMap did not report to the group carried by ctx")
+		}
+		for range Map(ctx, stream, func(_ context.Context, v int) int { return v }) {
+			t.Error("expected no values")
+		}
+		if err := g.Wait(); !errors.Is(err, errBoom) {
+			t.Errorf("Wait() = %v, want %v", err, errBoom)
+		}
+		if cause := context.Cause(ctx); !errors.Is(cause, errBoom) {
+			t.Errorf("context.Cause() = %v, want %v", cause, errBoom)
+		}
+		// Repeat is owned by the group, so it must have stopped.
+		for range numbers {
+		}
+	})
+
+	t.Run("first error wins", func(t *testing.T) {
+		t.Parallel()
+		g, ctx := WithGroup(t.Context())
+		errOther := errors.New("other")
+		a, _ := TryMap(ctx, From(ctx, 1), func(context.Context, int) (int, error) { return 0, errBoom })
+		for range a {
+		}
+		b, _ := TryMap(ctx, From(ctx, 1), func(context.Context, int) (int, error) { return 0, errOther })
+		for range b {
+		}
+		if err := g.Wait(); !errors.Is(err, errBoom) {
+			t.Errorf("Wait() = %v, want %v", err, errBoom)
+		}
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		t.Parallel()
+		g, ctx := WithGroup(t.Context())
+		stream, _ := TryMap(ctx, From(ctx, 1, 2), func(_ context.Context, v int) (int, error) { return v, nil })
+		var got []int
+		for v := range stream {
+			got = append(got, v)
+		}
+		checkStream(t, got, []int{1, 2})
+		if err := g.Wait(); err != nil {
+			t.Errorf("Wait() = %v, want nil", err)
+		}
+		if ctx.Err() == nil {
+			t.Error("expected group context to be canceled after Wait")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package conduit
+
+import (
+	"context"
+	"time"
+)
+
+// A KeyedStream is a substream of values that share the same key.
+type KeyedStream[K comparable, T any] struct {
+	Key    K
+	Stream <-chan T
+}
+
+// GroupBy returns a channel that emits a [KeyedStream] for each distinct key
+// in the input stream, where keyFn reports the key of each value. A group is
+// emitted the first time its key is seen, and every value is then routed to
+// its group's stream. All group streams are closed when the input stream
+// closes or the context is canceled.
+//
+// Use [WithMaxGroups] to cap the number of open groups, and
+// [WithIdleTimeout] to close groups that have gone quiet. A value whose group
+// has been closed starts a new group for its key.
+//
+// The groups are fed by a single goroutine, so every group stream must be
+// consumed concurrently with the others.
+func GroupBy[T any, K comparable](ctx context.Context, stream <-chan T, keyFn func(T) K, opts ...Option) <-chan KeyedStream[K, T] {
+	o := newOptions(opts)
+	out := make(chan KeyedStream[K, T], o.buffer)
+	go func() {
+		defer close(out)
+		groups := make(map[K]chan T)
+		defer func() {
+			for _, g := range groups {
+				close(g)
+			}
+		}()
+		defer recoverStage(ctx)
+		timer := time.NewTimer(o.idleTimeout)
+		timer.Stop()
+		defer timer.Stop()
+		var (
+			// activity orders groups by when they go idle, which is also
+			// least recently active first.
+			activity = newDeadlines[K]()
+			expired  <-chan time.Time
+		)
+		closeGroup := func(key K) {
+			close(groups[key])
+			delete(groups, key)
+			activity.remove(key)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					return
+				}
+				key := keyFn(v)
+				g, ok := groups[key]
+				if !ok {
+					if o.maxGroups > 0 && uint(len(groups)) >= o.maxGroups {
+						lru, _, _ := activity.peek()
+						closeGroup(lru)
+					}
+					g = make(chan T, o.buffer)
+					groups[key] = g
+					select {
+					case <-ctx.Done():
+						return
+					case out <- KeyedStream[K, T]{Key: key, Stream: g}:
+					}
+				}
+				activity.set(key, time.Now().Add(o.idleTimeout))
+				select {
+				case <-ctx.Done():
+					return
+				case g <- v:
+				}
+			case <-expired:
+				now := time.Now()
+				for key, at, ok := activity.peek(); ok && !at.After(now); key, at, ok = activity.peek() {
+					closeGroup(key)
+				}
+			}
+			if o.idleTimeout > 0 {
+				expired = activity.reset(timer)
+			}
+		}
+	}()
+	return out
+}
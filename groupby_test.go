@@ -0,0 +1,108 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collectGroups consumes every group concurrently and returns the keys in the
+// order their groups were emitted, along with the values of each group.
+func collectGroups(groups <-chan KeyedStream[string, string]) ([]string, [][]string) {
+	var (
+		wg     sync.WaitGroup
+		keys   []string
+		values []*[]string
+	)
+	for g := range groups {
+		keys = append(keys, g.Key)
+		var got []string
+		values = append(values, &got)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range g.Stream {
+				got = append(got, v)
+			}
+		}()
+	}
+	wg.Wait()
+	result := make([][]string, len(values))
+	for i, v := range values {
+		result[i] = *v
+	}
+	return keys, result
+}
+
+func TestGroupBy(t *testing.T) {
+	key := func(s string) string { return s[:1] }
+	tests := []struct {
+		name       string
+		setup      func(ctx context.Context) <-chan KeyedStream[string, string]
+		wantKeys   []string
+		wantValues [][]string
+	}{
+		{
+			name: "GroupBy",
+			setup: func(ctx context.Context) <-chan KeyedStream[string, string] {
+				return GroupBy(ctx, From(ctx, "a1", "b1", "a2", "c1", "b2"), key)
+			},
+			wantKeys:   []string{"a", "b", "c"},
+			wantValues: [][]string{{"a1", "a2"}, {"b1", "b2"}, {"c1"}},
+		},
+		{
+			name: "GroupBy max groups",
+			setup: func(ctx context.Context) <-chan KeyedStream[string, string] {
+				return GroupBy(ctx, From(ctx, "a1", "b1", "a2", "b2"), key, WithMaxGroups(2))
+			},
+			wantKeys:   []string{"a", "b"},
+			wantValues: [][]string{{"a1", "a2"}, {"b1", "b2"}},
+		},
+		{
+			name: "GroupBy evicts least recently active",
+			setup: func(ctx context.Context) <-chan KeyedStream[string, string] {
+				return GroupBy(ctx, From(ctx, "a1", "b1", "a2", "c1", "b2"), key, WithMaxGroups(2))
+			},
+			wantKeys:   []string{"a", "b", "c", "b"},
+			wantValues: [][]string{{"a1", "a2"}, {"b1"}, {"c1"}, {"b2"}},
+		},
+		{
+			name: "GroupBy idle timeout",
+			setup: func(ctx context.Context) <-chan KeyedStream[string, string] {
+				in := make(chan string)
+				go func() {
+					defer close(in)
+					in <- "a1"
+					time.Sleep(100 * time.Millisecond)
+					in <- "a2"
+				}()
+				return GroupBy(ctx, in, key, WithIdleTimeout(20*time.Millisecond))
+			},
+			wantKeys:   []string{"a", "a"},
+			wantValues: [][]string{{"a1"}, {"a2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			keys, values := collectGroups(tt.setup(t.Context()))
+			if !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("got keys %v, want %v", keys, tt.wantKeys)
+			}
+			if !slices.EqualFunc(values, tt.wantValues, slices.Equal) {
+				t.Errorf("got values %v, want %v", values, tt.wantValues)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, values := collectGroups(tt.setup(ctx))
+			if n := len(slices.Concat(values...)); n > 0 {
+				t.Errorf("expected no values after cancellation, got %v", values)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package conduit
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// FromReader returns a channel that emits the tokens read from r, as split by
+// split. If split is nil, [bufio.ScanLines] is used, so the channel emits the
+// lines of r without their line endings.
+//
+// The stage reports a read error to the [Group] carried by ctx (see
+// [WithGroup]), or to a new Group if ctx has none, and [Group.Wait] returns
+// it. Canceling the context does not interrupt a read that is already
+// blocked; close r to do so.
+func FromReader(ctx context.Context, r io.Reader, split bufio.SplitFunc, opts ...Option) (<-chan string, *Group) {
+	o := newOptions(opts)
+	g, ctx := groupFor(ctx)
+	out := make(chan string, o.buffer)
+	scanner := bufio.NewScanner(r)
+	if split != nil {
+		scanner.Split(split)
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer close(out)
+		defer recoverStage(ctx)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- scanner.Text():
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			g.fail(err)
+		}
+	}()
+	return out, g
+}
+
+// ToWriter writes encode(v) to w for each value from the input stream, until
+// the stream closes. It returns the first error from encode or w, or, if the
+// context is done first, the cause of its cancellation (see [context.Cause]),
+// such as the error that canceled the context of a [Group]. Once it has
+// returned, it no longer reads from the stream; if ctx carries a [Group], the
+// error is also reported to it, which stops the stages feeding the stream.
+func ToWriter[T any](ctx context.Context, w io.Writer, stream <-chan T, encode func(T) ([]byte, error)) error {
+	err := func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			case v, ok := <-stream:
+				if !ok {
+					return nil
+				}
+				b, err := encode(v)
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(b); err != nil {
+					return err
+				}
+			}
+		}
+	}()
+	if g, ok := ctx.Value(groupKey{}).(*Group); ok && err != nil {
+		g.fail(err)
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package conduit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFromReader(t *testing.T) {
+	errRead := errors.New("read failed")
+	tests := []struct {
+		name    string
+		r       io.Reader
+		split   bufio.SplitFunc
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "lines",
+			r:    strings.NewReader("one\ntwo\r\nthree"),
+			want: []string{"one", "two", "three"},
+		},
+		{
+			name:  "words",
+			r:     strings.NewReader(" one two\n\tthree "),
+			split: bufio.ScanWords,
+			want:  []string{"one", "two", "three"},
+		},
+		{
+			name: "empty",
+			r:    strings.NewReader(""),
+		},
+		{
+			name:    "read error",
+			r:       io.MultiReader(strings.NewReader("one\ntwo\n"), iotest.ErrReader(errRead)),
+			want:    []string{"one", "two"},
+			wantErr: errRead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, g := FromReader(t.Context(), tt.r, tt.split)
+			var got []string
+			for v := range out {
+				got = append(got, v)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if err := g.Wait(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		const lines = 100000
+		ctx, cancel := context.WithCancel(context.Background())
+		out, g := FromReader(ctx, strings.NewReader(strings.Repeat("x\n", lines)), nil)
+		<-out
+		cancel()
+		n := 1
+		for range out {
+			n++
+		}
+		if n == lines {
+			t.Errorf("got all %d lines after cancellation", n)
+		}
+		if err := g.Wait(); err != nil {
+			t.Errorf("got error %v, want nil", err)
+		}
+	})
+}
+
+// failingWriter fails every write after the first n bytes.
+type failingWriter struct {
+	n   int
+	err error
+	strings.Builder
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.Len()+len(p) > w.n {
+		return 0, w.err
+	}
+	return w.Builder.Write(p)
+}
+
+func TestToWriter(t *testing.T) {
+	errWrite := errors.New("write failed")
+	errEncode := errors.New("encode failed")
+	encode := func(s string) ([]byte, error) {
+		if s == "" {
+			return nil, errEncode
+		}
+		return []byte(s + "\n"), nil
+	}
+	tests := []struct {
+		name    string
+		values  []string
+		limit   int
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "ok",
+			values: []string{"one", "two"},
+			limit:  100,
+			want:   "one\ntwo\n",
+		},
+		{
+			name:    "write error",
+			values:  []string{"one", "two", "three"},
+			limit:   8,
+			want:    "one\ntwo\n",
+			wantErr: errWrite,
+		},
+		{
+			name:    "encode error",
+			values:  []string{"one", "", "three"},
+			limit:   100,
+			want:    "one\n",
+			wantErr: errEncode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, ctx := WithGroup(t.Context())
+			w := &failingWriter{n: tt.limit, err: errWrite}
+			err := ToWriter(ctx, w, From(ctx, tt.values...), encode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("wrote %q, want %q", got, tt.want)
+			}
+			if err := g.Wait(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("group: got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("failed group", func(t *testing.T) {
+		errStage := errors.New("stage failed")
+		g, ctx := WithGroup(t.Context())
+		g.fail(errStage)
+		err := ToWriter(ctx, io.Discard, make(chan string), encode)
+		if !errors.Is(err, errStage) {
+			t.Errorf("got error %v, want %v", err, errStage)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := ToWriter(ctx, io.Discard, make(chan string), encode)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+}
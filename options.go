@@ -0,0 +1,82 @@
+package conduit
+
+import "time"
+
+// An Option configures optional behavior of a stage. Options that do not
+// apply to a stage are ignored by it.
+type Option func(*options)
+
+type options struct {
+	buffer          uint
+	reorderBuffer   uint
+	outOfOrderness  time.Duration
+	allowedLateness time.Duration
+	maxGroups       uint
+	idleTimeout     time.Duration
+	subscriberBuf   uint
+	overflow        OverflowPolicy
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBuffer sets the capacity of the channels a stage returns, so that the
+// stage can run up to n values ahead of its consumer. By default, they are
+// unbuffered. Every stage that returns channels of its own accepts it, except
+// for [ChanChan], whose channels already hold every value they will emit, and
+// [FanOut], which returns the channels made by its fn. Stages with variadic
+// arguments, such as [From], accept it through their slice counterparts, such
+// as [FromSlice].
+func WithBuffer(n uint) Option {
+	return func(o *options) { o.buffer = n }
+}
+
+// WithReorderBuffer caps the number of results an order-preserving stage,
+// such as [ParallelMap], holds while it waits for an earlier, slower result.
+func WithReorderBuffer(n uint) Option {
+	return func(o *options) { o.reorderBuffer = n }
+}
+
+// WithOutOfOrderness sets how far behind the latest event time seen so far an
+// event-time stage, such as [EventTimeWindow], expects values to arrive. The
+// stage's watermark trails the latest event time by d.
+func WithOutOfOrderness(d time.Duration) Option {
+	return func(o *options) { o.outOfOrderness = d }
+}
+
+// WithAllowedLateness sets how long after the watermark has passed the end of
+// a window an event-time stage, such as [EventTimeWindow], still accepts
+// values for it.
+func WithAllowedLateness(d time.Duration) Option {
+	return func(o *options) { o.allowedLateness = d }
+}
+
+// WithMaxGroups caps the number of groups a keyed stage, such as [GroupBy],
+// keeps open at once. When the cap is reached, the least recently active group
+// is closed to make room for a new one.
+func WithMaxGroups(n uint) Option {
+	return func(o *options) { o.maxGroups = n }
+}
+
+// WithIdleTimeout makes a keyed stage, such as [GroupBy], close groups that
+// have not received a value for d.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *options) { o.idleTimeout = d }
+}
+
+// WithSubscriberBuffer sets the number of values a fan-out stage, such as
+// [Broadcast], buffers for each of its outputs.
+func WithSubscriberBuffer(n uint) Option {
+	return func(o *options) { o.subscriberBuf = n }
+}
+
+// WithOverflow sets what a stage does with a value when a consumer's buffer is
+// full. See [OverflowPolicy] for the available policies.
+func WithOverflow(p OverflowPolicy) Option {
+	return func(o *options) { o.overflow = p }
+}
@@ -0,0 +1,59 @@
+package conduit
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// A PanicError is the error reported when a stage function panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+// newPanicError returns a *PanicError for the value passed to panic. A value
+// that is already a *PanicError, re-raised after being recovered elsewhere, is
+// returned unchanged, so that it keeps its original value and stack.
+func newPanicError(v any) *PanicError {
+	if perr, ok := v.(*PanicError); ok {
+		return perr
+	}
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("conduit: panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the value passed to panic if it is an error, or nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// tryCall returns fn(ctx, v), converting a panic into a *PanicError.
+func tryCall[T, U any](ctx context.Context, fn func(context.Context, T) (U, error), v T) (val U, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+	return fn(ctx, v)
+}
+
+// recoverStage recovers a panic in the calling stage goroutine and reports it
+// to the [Group] carried by ctx. If ctx carries no group, the panic is left to
+// crash the program. It must be deferred directly, after the deferred close of
+// the stage's outputs, so that the outputs are closed once it has run.
+func recoverStage(ctx context.Context) {
+	g, ok := ctx.Value(groupKey{}).(*Group)
+	if !ok {
+		return
+	}
+	if r := recover(); r != nil {
+		g.fail(newPanicError(r))
+	}
+}
@@ -0,0 +1,117 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPanicError(t *testing.T) {
+	errCause := errors.New("cause")
+	tests := []struct {
+		name      string
+		value     any
+		wantCause error
+	}{
+		{name: "string", value: "boom"},
+		{name: "error", value: errCause, wantCause: errCause},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tryCall(t.Context(), func(context.Context, int) (int, error) {
+				panic(tt.value)
+			}, 1)
+			var perr *PanicError
+			if !errors.As(err, &perr) {
+				t.Fatalf("got %v, want a *PanicError", err)
+			}
+			if perr.Value != tt.value {
+				t.Errorf("got value %v, want %v", perr.Value, tt.value)
+			}
+			if !strings.Contains(err.Error(), "panic_test.go") {
+				t.Errorf("error does not contain the stack trace:\n%s", err)
+			}
+			if got := errors.Unwrap(err); got != tt.wantCause {
+				t.Errorf("Unwrap() = %v, want %v", got, tt.wantCause)
+			}
+		})
+	}
+}
+
+func TestRecoverStage(t *testing.T) {
+	boom := func(context.Context, int) int { panic("boom") }
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+	}{
+		{
+			name: "Map",
+			setup: func(ctx context.Context) <-chan int {
+				return Map(ctx, From(ctx, 1, 2, 3), boom)
+			},
+		},
+		{
+			name: "Skip",
+			setup: func(ctx context.Context) <-chan int {
+				return Skip(ctx, From(ctx, 1, 2, 3), func(context.Context, int) bool { panic("boom") })
+			},
+		},
+		{
+			name: "Repeat",
+			setup: func(ctx context.Context) <-chan int {
+				return Repeat(ctx, func(context.Context) int { panic("boom") })
+			},
+		},
+		{
+			name: "ChanChan",
+			setup: func(ctx context.Context) <-chan int {
+				return Bridge(ctx, ChanChan(ctx, 3, func(context.Context, uint) int { panic("boom") }))
+			},
+		},
+		{
+			name: "ParallelMap",
+			setup: func(ctx context.Context) <-chan int {
+				return ParallelMap(ctx, From(ctx, 1, 2, 3), 2, boom)
+			},
+		},
+		{
+			name: "WorkerPool",
+			setup: func(ctx context.Context) <-chan int {
+				out, _ := WorkerPool(ctx, From(ctx, 1, 2, 3), 2, boom)
+				return out
+			},
+		},
+		{
+			name: "Scan",
+			setup: func(ctx context.Context) <-chan int {
+				return Scan(ctx, From(ctx, 1, 2, 3), 0, func(context.Context, int, int) int { panic("boom") })
+			},
+		},
+		{
+			name: "ZipWith",
+			setup: func(ctx context.Context) <-chan int {
+				return ZipWith(ctx, From(ctx, 1), From(ctx, 2), func(context.Context, int, int) int { panic("boom") })
+			},
+		},
+		{
+			name: "Route",
+			setup: func(ctx context.Context) <-chan int {
+				return Route(ctx, From(ctx, 1, 2, 3), 1, func(int) int { panic("boom") })[0]
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g, ctx := WithGroup(t.Context())
+			for v := range tt.setup(ctx) {
+				t.Errorf("expected no values, got %v", v)
+			}
+			var perr *PanicError
+			if err := g.Wait(); !errors.As(err, &perr) || perr.Value != "boom" {
+				t.Errorf("Wait() = %v, want a *PanicError for %q", err, "boom")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package conduit
 
 import (
+	"container/heap"
 	"context"
 	"sync"
 )
@@ -9,8 +10,16 @@ import (
 // order of values is not preserved.
 // See [FanOut] for creating multiple input channels.
 // To preserve order, use [Bridge] with [ChanChan].
+// See [FanInSlice] for passing options.
 func FanIn[T any](ctx context.Context, streams ...<-chan T) <-chan T {
-	out := make(chan T)
+	return FanInSlice(ctx, streams)
+}
+
+// FanInSlice returns a channel that emits values from the input channels in
+// the provided slice. It is like [FanIn], but accepts options.
+func FanInSlice[T any](ctx context.Context, streams []<-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	var wg sync.WaitGroup
 	wg.Add(len(streams))
 	for _, stream := range streams {
@@ -32,8 +41,9 @@ func FanIn[T any](ctx context.Context, streams ...<-chan T) <-chan T {
 // Bridge returns a channel that emits values from a stream of inner channels
 // in order.
 // See [ChanChan] for creating a channel of channels.
-func Bridge[T any](ctx context.Context, chStream <-chan <-chan T) <-chan T {
-	out := make(chan T)
+func Bridge[T any](ctx context.Context, chStream <-chan <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
 		for {
@@ -61,8 +71,9 @@ func Bridge[T any](ctx context.Context, chStream <-chan <-chan T) <-chan T {
 // OrDone returns a channel that emits values from the input stream until it is
 // closed or the context is canceled. Useful for preventing goroutine leaks
 // when consuming from possibly-blocking or shared channels.
-func OrDone[T any](ctx context.Context, stream <-chan T) <-chan T {
-	out := make(chan T)
+func OrDone[T any](ctx context.Context, stream <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
 		for {
@@ -84,9 +95,11 @@ func OrDone[T any](ctx context.Context, stream <-chan T) <-chan T {
 }
 
 // Tee returns two channels that each emit the same values as the input stream.
-func Tee[T any](ctx context.Context, stream <-chan T) (_, _ <-chan T) {
-	out1 := make(chan T)
-	out2 := make(chan T)
+// See [Broadcast] for more outputs and for handling slow consumers.
+func Tee[T any](ctx context.Context, stream <-chan T, opts ...Option) (_, _ <-chan T) {
+	o := newOptions(opts)
+	out1 := make(chan T, o.buffer)
+	out2 := make(chan T, o.buffer)
 	go func() {
 		defer close(out1)
 		defer close(out2)
@@ -106,3 +119,259 @@ func Tee[T any](ctx context.Context, stream <-chan T) (_, _ <-chan T) {
 	}()
 	return out1, out2
 }
+
+// A Pair holds two values that were emitted together.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a channel that pairs up the values from two input streams, in
+// order. See [ZipWith] for details.
+func Zip[A, B any](ctx context.Context, a <-chan A, b <-chan B, opts ...Option) <-chan Pair[A, B] {
+	return ZipWith(ctx, a, b, func(_ context.Context, va A, vb B) Pair[A, B] {
+		return Pair[A, B]{First: va, Second: vb}
+	}, opts...)
+}
+
+// ZipWith returns a channel that emits the result of applying fn to the
+// values from two input streams, taken one from each stream at a time, in
+// order. It stops as soon as either stream closes, after which the other
+// stream is drained in the background until it closes or the context is
+// canceled, so that its producer is not blocked.
+func ZipWith[A, B, V any](ctx context.Context, a <-chan A, b <-chan B, fn func(context.Context, A, B) V, opts ...Option) <-chan V {
+	o := newOptions(opts)
+	out := make(chan V, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		for {
+			var (
+				va A
+				vb B
+				ok bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case va, ok = <-a:
+				if !ok {
+					drain(ctx, b)
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case vb, ok = <-b:
+				if !ok {
+					drain(ctx, a)
+					return
+				}
+			}
+			val := fn(ctx, va, vb)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- val:
+			}
+		}
+	}()
+	return out
+}
+
+// drain discards the values from stream in the background until it is closed
+// or the context is canceled.
+func drain[T any](ctx context.Context, stream <-chan T) {
+	go func() {
+		for range OrDone(ctx, stream) {
+		}
+	}()
+}
+
+// CombineLatest returns a channel that emits the result of applying fn to the
+// latest values from two input streams, whenever either stream emits a value,
+// once both have emitted at least one. It closes once both streams have
+// closed, or once either stream closes without having emitted a value, in
+// which case the other stream is drained in the background.
+func CombineLatest[A, B, V any](ctx context.Context, a <-chan A, b <-chan B, fn func(context.Context, A, B) V, opts ...Option) <-chan V {
+	o := newOptions(opts)
+	out := make(chan V, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		var (
+			va           A
+			vb           B
+			seenA, seenB bool
+		)
+		for a != nil || b != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-a:
+				if !ok {
+					a = nil
+					if !seenA {
+						if b != nil {
+							drain(ctx, b)
+						}
+						return
+					}
+					continue
+				}
+				va, seenA = v, true
+			case v, ok := <-b:
+				if !ok {
+					b = nil
+					if !seenB {
+						if a != nil {
+							drain(ctx, a)
+						}
+						return
+					}
+					continue
+				}
+				vb, seenB = v, true
+			}
+			if !seenA || !seenB {
+				continue
+			}
+			val := fn(ctx, va, vb)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- val:
+			}
+		}
+	}()
+	return out
+}
+
+// WithLatestFrom returns a channel that pairs each value from the main stream
+// with the latest value from the side stream. Values from the main stream that
+// arrive before the side stream has emitted a value are dropped. It closes
+// once the main stream closes, after which the side stream is drained in the
+// background.
+func WithLatestFrom[T, S any](ctx context.Context, main <-chan T, side <-chan S, opts ...Option) <-chan Pair[T, S] {
+	o := newOptions(opts)
+	out := make(chan Pair[T, S], o.buffer)
+	go func() {
+		defer close(out)
+		var (
+			latest S
+			seen   bool
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-side:
+				if !ok {
+					side = nil
+					continue
+				}
+				latest, seen = v, true
+			case v, ok := <-main:
+				if !ok {
+					if side != nil {
+						drain(ctx, side)
+					}
+					return
+				}
+				if !seen {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- Pair[T, S]{First: v, Second: latest}:
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// MergeSorted returns a channel that merges multiple input streams, each of
+// which must already be sorted according to less, into a single sorted
+// stream. Values are pulled lazily: at most one value from each stream is held
+// at a time. Values that compare equal are emitted in the order of the
+// streams that produced them.
+// See [MergeSortedSlice] for passing options.
+func MergeSorted[T any](ctx context.Context, less func(a, b T) bool, streams ...<-chan T) <-chan T {
+	return MergeSortedSlice(ctx, less, streams)
+}
+
+// MergeSortedSlice returns a channel that merges the sorted input streams in
+// the provided slice into a single sorted stream. It is like [MergeSorted],
+// but accepts options.
+func MergeSortedSlice[T any](ctx context.Context, less func(a, b T) bool, streams []<-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		h := &mergeHeap[T]{less: less}
+		// pull receives the next value from streams[i], if any, onto the heap.
+		pull := func(i int) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case v, ok := <-streams[i]:
+				if ok {
+					heap.Push(h, mergeHead[T]{v: v, stream: i})
+				}
+				return true
+			}
+		}
+		for i := range streams {
+			if !pull(i) {
+				return
+			}
+		}
+		for h.Len() > 0 {
+			head := heap.Pop(h).(mergeHead[T])
+			select {
+			case <-ctx.Done():
+				return
+			case out <- head.v:
+			}
+			if !pull(head.stream) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+type mergeHead[T any] struct {
+	v      T
+	stream int
+}
+
+// mergeHeap is a min-heap of the current head of each stream in [MergeSorted].
+type mergeHeap[T any] struct {
+	heads []mergeHead[T]
+	less  func(a, b T) bool
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.heads) }
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	if h.less(a.v, b.v) {
+		return true
+	}
+	if h.less(b.v, a.v) {
+		return false
+	}
+	return a.stream < b.stream
+}
+func (h *mergeHeap[T]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+func (h *mergeHeap[T]) Push(x any)    { h.heads = append(h.heads, x.(mergeHead[T])) }
+func (h *mergeHeap[T]) Pop() any {
+	n := len(h.heads)
+	head := h.heads[n-1]
+	h.heads = h.heads[:n-1]
+	return head
+}
@@ -2,9 +2,11 @@ package conduit
 
 import (
 	"context"
+	"runtime"
 	"slices"
 	"sync"
 	"testing"
+	"time"
 )
 
 func checkStream(t *testing.T, got, want []int) {
@@ -163,3 +165,209 @@ func TestBridge(t *testing.T) {
 		})
 	}
 }
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "Zip",
+			setup: func(ctx context.Context) <-chan int {
+				pairs := Zip(ctx, From(ctx, 1, 2, 3), From(ctx, "a", "bb", "ccc"))
+				return Map(ctx, pairs, func(_ context.Context, p Pair[int, string]) int {
+					return p.First * len(p.Second)
+				})
+			},
+			want: []int{1, 4, 9},
+		},
+		{
+			name: "ZipWith shorter first",
+			setup: func(ctx context.Context) <-chan int {
+				return ZipWith(ctx, From(ctx, 1, 2), Repeat(ctx, func(context.Context) int { return 10 }),
+					func(_ context.Context, a, b int) int { return a + b })
+			},
+			want: []int{11, 12},
+		},
+		{
+			name: "ZipWith shorter second",
+			setup: func(ctx context.Context) <-chan int {
+				return ZipWith(ctx, From(ctx, 1, 2, 3, 4), From(ctx, 10),
+					func(_ context.Context, a, b int) int { return a + b })
+			},
+			want: []int{11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+type send struct {
+	ch chan<- int
+	v  int
+}
+
+// sendInOrder performs each send in turn, then closes the channels listed in
+// closing. It stops early if the context is canceled.
+func sendInOrder(ctx context.Context, sends []send, closing ...chan<- int) {
+	go func() {
+		defer func() {
+			for _, ch := range closing {
+				close(ch)
+			}
+		}()
+		for _, s := range sends {
+			select {
+			case <-ctx.Done():
+				return
+			case s.ch <- s.v:
+			}
+		}
+	}()
+}
+
+func TestCombineLatest(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "CombineLatest",
+			setup: func(ctx context.Context) <-chan int {
+				a, b := make(chan int), make(chan int)
+				sendInOrder(ctx, []send{{a, 1}, {b, 10}, {a, 2}, {b, 20}}, a, b)
+				return CombineLatest(ctx, a, b, func(_ context.Context, va, vb int) int { return va + vb })
+			},
+			want: []int{11, 12, 22},
+		},
+		{
+			name: "CombineLatest empty input",
+			setup: func(ctx context.Context) <-chan int {
+				return CombineLatest(ctx, From[int](ctx), From(ctx, 1, 2, 3),
+					func(_ context.Context, va, vb int) int { return va + vb })
+			},
+			want: []int{},
+		},
+		{
+			name: "WithLatestFrom",
+			setup: func(ctx context.Context) <-chan int {
+				main, side := make(chan int), make(chan int)
+				sendInOrder(ctx, []send{
+					{main, 1}, {side, 10}, {main, 2}, {main, 3}, {side, 20}, {main, 4},
+				}, main, side)
+				pairs := WithLatestFrom(ctx, main, side)
+				return Map(ctx, pairs, func(_ context.Context, p Pair[int, int]) int {
+					return p.First + p.Second
+				})
+			},
+			want: []int{12, 13, 24},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+
+	t.Run("CombineLatest no leak", func(t *testing.T) {
+		// a emits and closes, then b closes without emitting. The stage may
+		// see either close first, so repeat to cover both orders.
+		before := runtime.NumGoroutine()
+		for range 20 {
+			a, b := make(chan int), make(chan int)
+			sendInOrder(context.Background(), []send{{a, 1}}, a, b)
+			for range CombineLatest(context.Background(), a, b, func(_ context.Context, va, vb int) int { return va + vb }) {
+				t.Fatal("expected no values")
+			}
+		}
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Fatalf("got %d goroutines, want at most %d", runtime.NumGoroutine(), before)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+func TestMergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "MergeSorted",
+			setup: func(ctx context.Context) <-chan int {
+				return MergeSorted(ctx, less,
+					From(ctx, 1, 4, 7),
+					From(ctx, 2, 5, 8, 9),
+					From[int](ctx),
+					From(ctx, 3, 6),
+				)
+			},
+			want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name: "MergeSorted no streams",
+			setup: func(ctx context.Context) <-chan int {
+				return MergeSorted(ctx, less)
+			},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			for v := range tt.setup(t.Context()) {
+				got = append(got, v)
+			}
+			// Unlike checkStream, the order itself is under test.
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	type item struct {
+		key    int
+		stream string
+	}
+	ctx := t.Context()
+	less := func(a, b item) bool { return a.key < b.key }
+	out := MergeSorted(ctx, less,
+		From(ctx, item{1, "a"}, item{2, "a"}),
+		From(ctx, item{1, "b"}, item{2, "b"}),
+	)
+	var got []item
+	for v := range out {
+		got = append(got, v)
+	}
+	want := []item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package conduit
+
+import (
+	"context"
+	"sync"
+)
+
+// A Pool is a handle to the workers of a [WorkerPool] stage. It is safe for
+// concurrent use.
+type Pool struct {
+	mu      sync.Mutex
+	size    uint
+	running uint
+	done    bool
+	wake    chan struct{} // closed to make idle workers re-check the size
+	spawn   func()
+	close   func()
+}
+
+// WorkerPool returns a channel that emits the results of applying fn to each
+// value from the input stream, using a pool of n workers. Results are emitted
+// as soon as they are ready, so the order of values is not preserved.
+// To preserve order, use [ParallelMap].
+//
+// The number of workers can be changed at runtime with [Pool.Resize]. The pool
+// always runs at least one worker; if n is 0, it is treated as 1.
+func WorkerPool[T, U any](ctx context.Context, stream <-chan T, n uint, fn func(context.Context, T) U, opts ...Option) (<-chan U, *Pool) {
+	o := newOptions(opts)
+	out := make(chan U, o.buffer)
+	p := &Pool{wake: make(chan struct{})}
+	p.close = func() { close(out) }
+	p.spawn = func() {
+		p.running++
+		go func() {
+			retired := false
+			defer func() {
+				if !retired {
+					p.exit()
+				}
+			}()
+			defer recoverStage(ctx)
+			for {
+				wake, ok := p.keep()
+				if !ok {
+					retired = true
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-wake:
+				case v, ok := <-stream:
+					if !ok {
+						return
+					}
+					val := fn(ctx, v)
+					select {
+					case <-ctx.Done():
+						return
+					case out <- val:
+					}
+				}
+			}
+		}()
+	}
+	p.Resize(n)
+	return out, p
+}
+
+// Size returns the number of workers the pool is scaled to.
+func (p *Pool) Size() uint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+// Resize scales the pool to n workers. New workers are started immediately.
+// When shrinking, idle workers stop immediately, and busy workers stop once
+// they have emitted their current result. If n is 0, it is treated as 1.
+// Resize has no effect once the stage has finished.
+func (p *Pool) Resize(n uint) {
+	n = max(n, 1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.size = n
+	for p.running < n {
+		p.spawn()
+	}
+	close(p.wake)
+	p.wake = make(chan struct{})
+}
+
+// keep reports whether the calling worker should keep running, and if so,
+// returns the channel that is closed on the next resize. A worker that
+// should not keep running is no longer counted as running.
+func (p *Pool) keep() (<-chan struct{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running > p.size {
+		p.running--
+		return nil, false
+	}
+	return p.wake, true
+}
+
+// exit is called when a worker stops for any reason other than the pool
+// shrinking. The last worker to stop closes the output.
+func (p *Pool) exit() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running--
+	if p.running == 0 {
+		p.done = true
+		p.close()
+	}
+}
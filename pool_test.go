@@ -0,0 +1,116 @@
+package conduit
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "WorkerPool",
+			setup: func(ctx context.Context) <-chan int {
+				stream, _ := WorkerPool(ctx, From(ctx, 1, 2, 3), 2, func(_ context.Context, v int) int {
+					return v * 10
+				})
+				return stream
+			},
+			want: []int{10, 20, 30},
+		},
+		{
+			name: "WorkerPool zero workers",
+			setup: func(ctx context.Context) <-chan int {
+				stream, _ := WorkerPool(ctx, From(ctx, 1, 2, 3), 0, func(_ context.Context, v int) int {
+					return v
+				})
+				return stream
+			},
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+func TestPoolResize(t *testing.T) {
+	ctx := t.Context()
+	var running atomic.Int32
+	release := make(chan struct{})
+	in := make(chan int)
+	stream, p := WorkerPool(ctx, in, 1, func(_ context.Context, v int) int {
+		running.Add(1)
+		defer running.Add(-1)
+		<-release
+		return v
+	})
+
+	waitRunning := func(want int32) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for running.Load() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("running = %d, want %d", running.Load(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	p.Resize(3)
+	if got := p.Size(); got != 3 {
+		t.Fatalf("Size() = %d, want 3", got)
+	}
+	for i := range 3 {
+		in <- i
+	}
+	waitRunning(3)
+	for range 3 {
+		release <- struct{}{}
+		<-stream
+	}
+
+	p.Resize(1)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		p.mu.Lock()
+		n := p.running
+		p.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("running workers = %d, want 1", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	in <- 1
+	waitRunning(1)
+	// Only one worker is left, so the next value is not picked up until
+	// the busy worker is released.
+	select {
+	case in <- 2:
+		t.Fatal("expected the pool to have a single worker")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release <- struct{}{}
+	<-stream
+
+	close(in)
+	for range stream {
+		t.Error("expected no values")
+	}
+	p.Resize(2) // no-op after the stage has finished
+}
@@ -6,8 +6,16 @@ import (
 )
 
 // From returns a channel that emits the provided values.
+// See [FromSlice] for passing options.
 func From[T any](ctx context.Context, values ...T) <-chan T {
-	out := make(chan T)
+	return FromSlice(ctx, values)
+}
+
+// FromSlice returns a channel that emits the values of the provided slice. It
+// is like [From], but accepts options.
+func FromSlice[T any](ctx context.Context, values []T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
 		for _, v := range values {
@@ -22,10 +30,12 @@ func From[T any](ctx context.Context, values ...T) <-chan T {
 }
 
 // FromSeq returns a channel that emits values from the provided sequence.
-func FromSeq[V any](ctx context.Context, seq iter.Seq[V]) <-chan V {
-	out := make(chan V)
+func FromSeq[V any](ctx context.Context, seq iter.Seq[V], opts ...Option) <-chan V {
+	o := newOptions(opts)
+	out := make(chan V, o.buffer)
 	go func() {
 		defer close(out)
+		defer recoverStage(ctx)
 		for v := range seq {
 			select {
 			case <-ctx.Done():
@@ -39,10 +49,12 @@ func FromSeq[V any](ctx context.Context, seq iter.Seq[V]) <-chan V {
 
 // FromSeq2 returns a channel that emits values from the key-value pairs in the
 // provided sequence.
-func FromSeq2[K, V any](ctx context.Context, seq iter.Seq2[K, V]) <-chan V {
-	out := make(chan V)
+func FromSeq2[K, V any](ctx context.Context, seq iter.Seq2[K, V], opts ...Option) <-chan V {
+	o := newOptions(opts)
+	out := make(chan V, o.buffer)
 	go func() {
 		defer close(out)
+		defer recoverStage(ctx)
 		for _, v := range seq {
 			select {
 			case <-ctx.Done():
@@ -56,10 +68,12 @@ func FromSeq2[K, V any](ctx context.Context, seq iter.Seq2[K, V]) <-chan V {
 
 // Repeat returns a channel that emits values by repeatedly calling fn(ctx)
 // until the context is canceled. Useful for generating infinite streams.
-func Repeat[T any](ctx context.Context, fn func(context.Context) T) <-chan T {
-	out := make(chan T)
+func Repeat[T any](ctx context.Context, fn func(context.Context) T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
 	go func() {
 		defer close(out)
+		defer recoverStage(ctx)
 		for {
 			val := fn(ctx)
 			select {
@@ -83,6 +97,7 @@ func ChanChan[T any](ctx context.Context, n uint, fn func(ctx context.Context, i
 			stream := make(chan T, 1)
 			go func(index uint) {
 				defer close(stream)
+				defer recoverStage(ctx)
 				val := fn(ctx, index)
 				select {
 				case <-ctx.Done():
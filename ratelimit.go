@@ -0,0 +1,103 @@
+package conduit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A Limiter paces events using a token bucket. The bucket holds up to burst
+// tokens and is refilled at rate tokens per second. A Limiter is safe for
+// concurrent use, so a single Limiter can pace several stages, for example
+// the branches of a [FanOut].
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a [Limiter] that allows rate events per second, with
+// bursts of up to burst events. The bucket starts full. If burst is 0, it is
+// treated as 1.
+//
+// NewLimiter panics if rate is not positive.
+func NewLimiter(rate float64, burst uint) *Limiter {
+	if rate <= 0 {
+		panic("conduit: non-positive rate for NewLimiter")
+	}
+	b := float64(max(burst, 1))
+	return &Limiter{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+// Wait blocks until n tokens are available and takes them, or until the
+// context is done, in which case it returns the context's error and takes no
+// tokens. A request for more tokens than the burst size is allowed, and waits
+// for the bucket to refill accordingly.
+func (l *Limiter) Wait(ctx context.Context, n uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve the tokens up front so that concurrent callers queue up
+	// behind each other.
+	l.tokens -= float64(n)
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+	if sleep(ctx, wait) {
+		return nil
+	}
+	l.mu.Lock()
+	l.tokens += float64(n)
+	l.mu.Unlock()
+	return ctx.Err()
+}
+
+// RateLimit returns a channel that emits the values from the input stream at
+// no more than rate values per second, allowing bursts of up to burst values.
+// See [RateLimitWith] for sharing a limit between stages.
+func RateLimit[T any](ctx context.Context, stream <-chan T, rate float64, burst uint, opts ...Option) <-chan T {
+	return RateLimitWith(ctx, stream, NewLimiter(rate, burst), nil, opts...)
+}
+
+// RateLimitWith returns a channel that emits the values from the input stream
+// as permitted by l, where each value takes cost(v) tokens. If cost is nil,
+// each value takes one token.
+func RateLimitWith[T any](ctx context.Context, stream <-chan T, l *Limiter, cost func(T) uint, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					return
+				}
+				n := uint(1)
+				if cost != nil {
+					n = cost(v)
+				}
+				if l.Wait(ctx, n) != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- v:
+				}
+			}
+		}
+	}()
+	return out
+}
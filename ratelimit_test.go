@@ -0,0 +1,111 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "RateLimit",
+			setup: func(ctx context.Context) <-chan int {
+				return RateLimit(ctx, From(ctx, 1, 2, 3), 1000, 1)
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "RateLimitWith",
+			setup: func(ctx context.Context) <-chan int {
+				return RateLimitWith(ctx, From(ctx, 1, 2, 3), NewLimiter(1000, 10),
+					func(v int) uint { return uint(v) })
+			},
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+func TestRateLimitPacing(t *testing.T) {
+	const rate = 100 // one token every 10ms
+	tests := []struct {
+		name    string
+		setup   func(ctx context.Context) <-chan int
+		minTime time.Duration
+	}{
+		{
+			name: "burst then paced",
+			setup: func(ctx context.Context) <-chan int {
+				// 2 values pass in the burst, the other 4 wait 10ms each.
+				return RateLimit(ctx, From(ctx, 1, 2, 3, 4, 5, 6), rate, 2)
+			},
+			minTime: 40 * time.Millisecond,
+		},
+		{
+			name: "cost",
+			setup: func(ctx context.Context) <-chan int {
+				// 1 token from the burst, then 5 tokens at 10ms each.
+				return RateLimitWith(ctx, From(ctx, 1, 5), NewLimiter(rate, 1), func(v int) uint { return uint(v) })
+			},
+			minTime: 50 * time.Millisecond,
+		},
+		{
+			name: "shared limiter",
+			setup: func(ctx context.Context) <-chan int {
+				// 1 token from the burst, then 5 tokens at 10ms each.
+				l := NewLimiter(rate, 1)
+				streams := FanOut(ctx, 2, func(ctx context.Context, _ uint) <-chan int {
+					return RateLimitWith(ctx, From(ctx, 1, 2, 3), l, nil)
+				})
+				return FanIn(ctx, streams...)
+			},
+			minTime: 50 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			start := time.Now()
+			for range tt.setup(t.Context()) {
+			}
+			// Allow for timer granularity.
+			if elapsed := time.Since(start); elapsed < tt.minTime*9/10 {
+				t.Errorf("took %v, want at least %v", elapsed, tt.minTime)
+			}
+		})
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	l := NewLimiter(1, 1)
+	if err := l.Wait(t.Context(), 1); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The cancelled wait must not have consumed tokens.
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.01 {
+		t.Errorf("tokens = %v, want the cancelled reservation refunded", tokens)
+	}
+}
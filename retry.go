@@ -0,0 +1,123 @@
+package conduit
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// A Backoff returns how long to wait before the given retry, where attempt is
+// 1 for the first retry and prev is the previous wait (0 for the first retry).
+type Backoff func(attempt uint, prev time.Duration) time.Duration
+
+// ConstantBackoff returns a [Backoff] that always waits d.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(uint, time.Duration) time.Duration { return d }
+}
+
+// ExponentialBackoff returns a [Backoff] that waits base before the first
+// retry and doubles the wait for each retry after that, up to limit.
+func ExponentialBackoff(base, limit time.Duration) Backoff {
+	return func(attempt uint, _ time.Duration) time.Duration {
+		d := base
+		for range attempt - 1 {
+			if d >= limit/2 {
+				return limit
+			}
+			d *= 2
+		}
+		return min(d, limit)
+	}
+}
+
+// DecorrelatedJitterBackoff returns a [Backoff] that waits a random duration
+// between base and three times the previous wait, up to limit. Randomizing
+// the waits spreads out retries from many callers that failed at the same
+// time.
+func DecorrelatedJitterBackoff(base, limit time.Duration) Backoff {
+	return func(_ uint, prev time.Duration) time.Duration {
+		hi := max(prev*3, base)
+		d := base + rand.N(hi-base+1)
+		return min(d, limit)
+	}
+}
+
+// RetryForever can be used as [RetryPolicy.MaxAttempts] to retry calls until
+// they succeed, fail with an error that is not retryable, or the context is
+// done.
+const RetryForever = ^uint(0)
+
+// defaultMaxAttempts is the number of calls made when
+// [RetryPolicy.MaxAttempts] is 0.
+const defaultMaxAttempts = 3
+
+// A RetryPolicy configures [Retry]. The zero value makes up to three calls,
+// with no wait between them.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one.
+	// If it is 0, three calls are made. Retrying without limit must be asked
+	// for with [RetryForever].
+	MaxAttempts uint
+
+	// Backoff reports how long to wait before each retry. If it is nil,
+	// calls are retried immediately.
+	Backoff Backoff
+
+	// Retryable reports whether a call that failed with err should be
+	// retried. If it is nil, all errors are retried.
+	Retryable func(err error) bool
+}
+
+// Retry returns a function that calls fn, retrying failed calls according to
+// the policy. It is meant to wrap the function passed to a fallible stage such
+// as [TryMap].
+//
+// Waits between retries end early when the context is done, and a retry that
+// could not start before the context's deadline is not attempted. In both
+// cases, and once the attempts are exhausted, the error from the last call is
+// returned.
+func Retry[T, U any](policy RetryPolicy, fn func(context.Context, T) (U, error)) func(context.Context, T) (U, error) {
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = defaultMaxAttempts
+	}
+	return func(ctx context.Context, v T) (U, error) {
+		var wait time.Duration
+		for attempt := uint(1); ; attempt++ {
+			val, err := fn(ctx, v)
+			if err == nil {
+				return val, nil
+			}
+			if attempt == policy.MaxAttempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+				return val, err
+			}
+			if policy.Backoff != nil {
+				wait = policy.Backoff(attempt, wait)
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return val, err
+			}
+			if !sleep(ctx, wait) {
+				return val, err
+			}
+		}
+	}
+}
+
+// sleep waits for d, and reports whether it did so before the context was
+// done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
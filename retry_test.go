@@ -0,0 +1,170 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		want    []time.Duration
+	}{
+		{
+			name:    "ConstantBackoff",
+			backoff: ConstantBackoff(time.Second),
+			want:    []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:    "ExponentialBackoff",
+			backoff: ExponentialBackoff(time.Second, 5*time.Second),
+			want:    []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prev time.Duration
+			for i, want := range tt.want {
+				got := tt.backoff(uint(i+1), prev)
+				if got != want {
+					t.Errorf("attempt %d: got %v, want %v", i+1, got, want)
+				}
+				prev = got
+			}
+		})
+	}
+
+	t.Run("DecorrelatedJitterBackoff", func(t *testing.T) {
+		const base, limit = 10 * time.Millisecond, time.Second
+		backoff := DecorrelatedJitterBackoff(base, limit)
+		var prev time.Duration
+		for attempt := uint(1); attempt <= 50; attempt++ {
+			got := backoff(attempt, prev)
+			if got < base || got > limit || got > max(prev*3, base) {
+				t.Fatalf("attempt %d: got %v, want within [%v, min(%v, %v)]", attempt, got, base, max(prev*3, base), limit)
+			}
+			prev = got
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	errFatal := errors.New("fatal")
+	// failing returns a function that fails with err n times, then succeeds,
+	// along with a pointer to the number of calls made.
+	failing := func(n int, err error) (func(context.Context, int) (int, error), *int) {
+		calls := 0
+		return func(_ context.Context, v int) (int, error) {
+			calls++
+			if calls <= n {
+				return 0, err
+			}
+			return v * 10, nil
+		}, &calls
+	}
+
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		policy    RetryPolicy
+		timeout   time.Duration
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "succeeds after retries",
+			failures:  2,
+			err:       errFlaky,
+			policy:    RetryPolicy{MaxAttempts: 3},
+			wantCalls: 3,
+		},
+		{
+			name:      "attempts exhausted",
+			failures:  5,
+			err:       errFlaky,
+			policy:    RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff(time.Millisecond)},
+			wantErr:   errFlaky,
+			wantCalls: 3,
+		},
+		{
+			name:      "default attempts",
+			failures:  5,
+			err:       errFlaky,
+			policy:    RetryPolicy{},
+			wantErr:   errFlaky,
+			wantCalls: 3,
+		},
+		{
+			name:      "unlimited attempts",
+			failures:  10,
+			err:       errFlaky,
+			policy:    RetryPolicy{MaxAttempts: RetryForever},
+			wantCalls: 11,
+		},
+		{
+			name:     "not retryable",
+			failures: 5,
+			err:      errFatal,
+			policy: RetryPolicy{
+				MaxAttempts: 3,
+				Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+			},
+			wantErr:   errFatal,
+			wantCalls: 1,
+		},
+		{
+			name:      "wait exceeds deadline",
+			failures:  5,
+			err:       errFlaky,
+			policy:    RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff(time.Hour)},
+			timeout:   time.Second,
+			wantErr:   errFlaky,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			fn, calls := failing(tt.failures, tt.err)
+			got, err := Retry(tt.policy, fn)(ctx, 4)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != 40 {
+				t.Errorf("got %d, want 40", got)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		fn, calls := failing(5, errFlaky)
+		time.AfterFunc(10*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := Retry(RetryPolicy{MaxAttempts: RetryForever, Backoff: ConstantBackoff(time.Hour)}, fn)(ctx, 1)
+		if !errors.Is(err, errFlaky) {
+			t.Errorf("got error %v, want %v", err, errFlaky)
+		}
+		if *calls != 1 {
+			t.Errorf("got %d calls, want 1", *calls)
+		}
+		if elapsed := time.Since(start); elapsed > time.Minute {
+			t.Errorf("wait did not end on cancellation, took %v", elapsed)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package conduit
+
+import (
+	"context"
+	"hash/maphash"
+)
+
+// ShardBy returns a channel that emits the results of applying fn to each
+// value from the input stream, using shards workers. Each value is routed to
+// a worker by hashing the key reported by keyFn, so values with the same key
+// are always processed, and emitted, in input order by the same worker, while
+// values with different keys are processed in parallel. The order of values
+// with different keys is not preserved. If shards is 0, it is treated as 1.
+func ShardBy[T any, K comparable, U any](ctx context.Context, stream <-chan T, shards uint, keyFn func(T) K, fn func(context.Context, T) U, opts ...Option) <-chan U {
+	shards = max(shards, 1)
+	seed := maphash.MakeSeed()
+	inputs := make([]chan T, shards)
+	outputs := make([]<-chan U, shards)
+	for i := range inputs {
+		inputs[i] = make(chan T)
+		outputs[i] = Map(ctx, inputs[i], fn)
+	}
+	go func() {
+		defer func() {
+			for _, in := range inputs {
+				close(in)
+			}
+		}()
+		defer recoverStage(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					return
+				}
+				shard := maphash.Comparable(seed, keyFn(v)) % uint64(shards)
+				select {
+				case <-ctx.Done():
+					return
+				case inputs[shard] <- v:
+				}
+			}
+		}
+	}()
+	return FanInSlice(ctx, outputs, opts...)
+}
@@ -0,0 +1,76 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShardBy(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "ShardBy",
+			setup: func(ctx context.Context) <-chan int {
+				return ShardBy(ctx, From(ctx, 1, 2, 3, 4), 2, func(v int) int { return v % 2 },
+					func(_ context.Context, v int) int { return v * 10 })
+			},
+			want: []int{10, 20, 30, 40},
+		},
+		{
+			name: "ShardBy zero shards",
+			setup: func(ctx context.Context) <-chan int {
+				return ShardBy(ctx, From(ctx, 1, 2, 3), 0, func(v int) int { return v },
+					func(_ context.Context, v int) int { return v })
+			},
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			runStreamTest(t, tt.setup, tt.want)
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
+
+func TestShardByKeyOrder(t *testing.T) {
+	type event struct {
+		account string
+		seq     int
+	}
+	var values []event
+	for seq := range 100 {
+		for _, account := range []string{"a", "b", "c", "d"} {
+			values = append(values, event{account, seq})
+		}
+	}
+	ctx := t.Context()
+	stream := ShardBy(ctx, From(ctx, values...), 3, func(e event) string { return e.account },
+		func(_ context.Context, e event) event {
+			if e.seq%7 == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			return e
+		})
+	got := make(map[string][]int)
+	for e := range stream {
+		got[e.account] = append(got[e.account], e.seq)
+	}
+	if len(got) != 4 {
+		t.Errorf("got %d accounts, want 4", len(got))
+	}
+	for account, seqs := range got {
+		if len(seqs) != 100 || !slices.IsSorted(seqs) {
+			t.Errorf("account %s: got %v, want 100 values in order", account, seqs)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package conduit
+
+import (
+	"context"
+	"time"
+)
+
+// Debounce returns a channel that emits a value from the input stream only
+// once no other value has been received for the quiet period; values
+// superseded within the period are dropped. A pending value is emitted when
+// the input stream closes, but not when the context is canceled.
+func Debounce[T any](ctx context.Context, stream <-chan T, quiet time.Duration, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+		defer timer.Stop()
+		var (
+			pending T
+			fire    <-chan time.Time
+		)
+		emit := func() bool {
+			fire = nil
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- pending:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					if fire != nil {
+						emit()
+					}
+					return
+				}
+				pending = v
+				timer.Reset(quiet)
+				fire = timer.C
+			case <-fire:
+				if !emit() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ThrottleFirst returns a channel that emits a value from the input stream,
+// then drops the values received during the following interval.
+func ThrottleFirst[T any](ctx context.Context, stream <-chan T, interval time.Duration, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		var until time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					return
+				}
+				now := time.Now()
+				if now.Before(until) {
+					continue
+				}
+				until = now.Add(interval)
+				select {
+				case <-ctx.Done():
+					return
+				case out <- v:
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ThrottleLast returns a channel that emits, at the end of every interval, the
+// latest value received from the input stream during that interval, if any.
+// See [Sample] for details.
+//
+// ThrottleLast panics if interval is not positive.
+func ThrottleLast[T any](ctx context.Context, stream <-chan T, interval time.Duration, opts ...Option) <-chan T {
+	ticker := time.NewTicker(interval)
+	return sample(ctx, stream, ticker.C, ticker.Stop, opts...)
+}
+
+// Sample returns a channel that emits, whenever ticker fires, the latest value
+// received from the input stream since it last fired, if any. A pending value
+// is emitted when the input stream closes, but not when the context is
+// canceled. The caller remains responsible for stopping the ticker.
+func Sample[T any](ctx context.Context, stream <-chan T, ticker <-chan time.Time, opts ...Option) <-chan T {
+	return sample(ctx, stream, ticker, func() {}, opts...)
+}
+
+// sample implements [Sample], calling stop once the stage has finished.
+func sample[T any](ctx context.Context, stream <-chan T, ticker <-chan time.Time, stop func(), opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		defer stop()
+		var (
+			latest  T
+			pending bool
+		)
+		emit := func() bool {
+			pending = false
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- latest:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					if pending {
+						emit()
+					}
+					return
+				}
+				latest, pending = v, true
+			case <-ticker:
+				if pending && !emit() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,86 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	const unit = 50 * time.Millisecond
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan int
+		want  []int
+	}{
+		{
+			name: "Debounce",
+			setup: func(ctx context.Context) <-chan int {
+				stream := sendAfter(ctx, []int{1, 2, 3}, []time.Duration{0, 0, 3 * unit, 0})
+				return Debounce(ctx, stream, unit)
+			},
+			want: []int{2, 3},
+		},
+		{
+			name: "ThrottleFirst",
+			setup: func(ctx context.Context) <-chan int {
+				stream := sendAfter(ctx, []int{1, 2, 3}, []time.Duration{0, 0, 3 * unit, 0})
+				return ThrottleFirst(ctx, stream, 2*unit)
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "ThrottleLast",
+			setup: func(ctx context.Context) <-chan int {
+				stream := sendAfter(ctx, []int{1, 2, 3}, []time.Duration{0, 0, 3 * unit, 0})
+				return ThrottleLast(ctx, stream, 2*unit)
+			},
+			want: []int{2, 3},
+		},
+		{
+			name: "Sample",
+			setup: func(ctx context.Context) <-chan int {
+				in, ticker := make(chan int), make(chan time.Time)
+				const tick = -1
+				go func() {
+					defer close(in)
+					// The second tick has nothing pending.
+					for _, v := range []int{1, 2, tick, tick, 3} {
+						if v == tick {
+							select {
+							case <-ctx.Done():
+								return
+							case ticker <- time.Now():
+							}
+							continue
+						}
+						select {
+						case <-ctx.Done():
+							return
+						case in <- v:
+						}
+					}
+				}()
+				return Sample(ctx, in, ticker)
+			},
+			want: []int{2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			for v := range tt.setup(t.Context()) {
+				got = append(got, v)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			runCancelledStreamTest(t, tt.setup)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// A TimeoutError is returned by a function wrapped with [Timeout] when a call
+// does not finish in time.
+type TimeoutError struct {
+	// Value is the value the call was processing.
+	Value any
+	// Timeout is the time the call was given.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("conduit: call for %v timed out after %v", e.Value, e.Timeout)
+}
+
+// Unwrap returns [context.DeadlineExceeded].
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Timeout returns a function that calls fn under its own context, derived from
+// the caller's, with a deadline d from the start of the call. If the call has
+// not returned by then, the function returns a *[TimeoutError] without waiting
+// for it, so that a hung call does not stall the stage; fn should still
+// return once its context is done. It is meant to wrap the function passed to
+// a fallible stage such as [TryMap] or [MapDeadLetter].
+//
+// A panic in fn is returned as a *[PanicError].
+func Timeout[T, U any](d time.Duration, fn func(context.Context, T) (U, error)) func(context.Context, T) (U, error) {
+	return func(ctx context.Context, v T) (U, error) {
+		callCtx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		type result struct {
+			val U
+			err error
+		}
+		done := make(chan result, 1)
+		go func() {
+			val, err := tryCall(callCtx, fn, v)
+			done <- result{val, err}
+		}()
+		select {
+		case r := <-done:
+			return r.val, r.err
+		case <-callCtx.Done():
+			var zero U
+			if err := ctx.Err(); err != nil {
+				return zero, err
+			}
+			return zero, &TimeoutError{Value: v, Timeout: d}
+		}
+	}
+}
+
+// TimeoutOr is like [Timeout], but for the function passed to [Map] and
+// similar stages: a call to fn that does not return within d is replaced by a
+// call to fallback.
+func TimeoutOr[T, U any](d time.Duration, fn, fallback func(context.Context, T) U) func(context.Context, T) U {
+	call := Timeout(d, func(ctx context.Context, v T) (U, error) {
+		return fn(ctx, v), nil
+	})
+	return func(ctx context.Context, v T) U {
+		val, err := call(ctx, v)
+		var perr *PanicError
+		if errors.As(err, &perr) {
+			panic(perr)
+		}
+		if err != nil {
+			return fallback(ctx, v)
+		}
+		return val
+	}
+}
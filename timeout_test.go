@@ -0,0 +1,142 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// hang is a value for which hangOr blocks until its context is done.
+const hang = -1
+
+// hangOr returns v*10 at once, unless v is hang, in which case it blocks until
+// its context is done. Calls that return at once never race their deadline.
+func hangOr(ctx context.Context, v int) (int, error) {
+	if v == hang {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	return v * 10, nil
+}
+
+func TestTimeout(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name    string
+		fn      func(context.Context, int) (int, error)
+		v       int
+		timeout time.Duration
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "in time",
+			fn:      hangOr,
+			v:       1,
+			timeout: time.Second,
+			want:    10,
+		},
+		{
+			name:    "timed out",
+			fn:      hangOr,
+			v:       hang,
+			timeout: 20 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "hung call",
+			fn: func(context.Context, int) (int, error) {
+				select {}
+			},
+			v:       1,
+			timeout: 20 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "error",
+			fn:      func(context.Context, int) (int, error) { return 0, errBoom },
+			timeout: time.Second,
+			wantErr: errBoom,
+		},
+		{
+			name:    "panic",
+			fn:      func(context.Context, int) (int, error) { panic(errBoom) },
+			timeout: time.Second,
+			wantErr: errBoom,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Timeout(tt.timeout, tt.fn)(context.Background(), tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("TimeoutError", func(t *testing.T) {
+		_, err := Timeout(time.Millisecond, hangOr)(context.Background(), hang)
+		var terr *TimeoutError
+		if !errors.As(err, &terr) {
+			t.Fatalf("got error %v, want *TimeoutError", err)
+		}
+		if terr.Value != hang || terr.Timeout != time.Millisecond {
+			t.Errorf("got %+v, want Value %d and Timeout 1ms", terr, hang)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Timeout(time.Second, hangOr)(ctx, hang)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("TryMap", func(t *testing.T) {
+		ctx := context.Background()
+		out, g := TryMap(ctx, From(ctx, 1, hang), Timeout(time.Second, hangOr))
+		var got []int
+		for v := range out {
+			got = append(got, v)
+		}
+		checkStream(t, got, []int{10})
+		var terr *TimeoutError
+		if err := g.Wait(); !errors.As(err, &terr) || terr.Value != hang {
+			t.Errorf("got error %v, want *TimeoutError for %d", err, hang)
+		}
+	})
+}
+
+func TestTimeoutOr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fn := func(ctx context.Context, v int) int {
+		val, _ := hangOr(ctx, v)
+		return val
+	}
+	fallback := func(context.Context, int) int { return -1 }
+	out := Map(ctx, From(ctx, 1, hang, 2), TimeoutOr(time.Second, fn, fallback))
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	checkStream(t, got, []int{-1, 10, 20})
+
+	t.Run("panic", func(t *testing.T) {
+		g, ctx := WithGroup(context.Background())
+		boom := func(context.Context, int) int { panic("boom") }
+		out := Map(ctx, From(ctx, 1), TimeoutOr(time.Second, boom, fallback))
+		for range out {
+		}
+		var perr *PanicError
+		if err := g.Wait(); !errors.As(err, &perr) || perr.Value != "boom" {
+			t.Errorf("got error %v, want *PanicError with value %q", err, "boom")
+		}
+	})
+}
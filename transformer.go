@@ -2,14 +2,17 @@ package conduit
 
 import (
 	"context"
+	"time"
 )
 
 // Map returns a channel that emits the results of applying fn to each value
 // from the input stream.
-func Map[T, U any](ctx context.Context, stream <-chan T, fn func(context.Context, T) U) <-chan U {
-	out := make(chan U)
+func Map[T, U any](ctx context.Context, stream <-chan T, fn func(context.Context, T) U, opts ...Option) <-chan U {
+	o := newOptions(opts)
+	out := make(chan U, o.buffer)
 	go func() {
 		defer close(out)
+		defer recoverStage(ctx)
 		for v := range stream {
 			val := fn(ctx, v)
 			select {
@@ -21,3 +24,168 @@ func Map[T, U any](ctx context.Context, stream <-chan T, fn func(context.Context
 	}()
 	return out
 }
+
+// TryMap returns a channel that emits the results of applying fn to each value
+// from the input stream, stopping at the first error returned by fn.
+//
+// The stage reports to the [Group] carried by ctx (see [WithGroup]), or to a
+// new Group if ctx has none. The first error cancels the group's context,
+// which stops every stage built with it, and is returned by [Group.Wait].
+func TryMap[T, U any](ctx context.Context, stream <-chan T, fn func(context.Context, T) (U, error), opts ...Option) (<-chan U, *Group) {
+	o := newOptions(opts)
+	g, ctx := groupFor(ctx)
+	out := make(chan U, o.buffer)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer close(out)
+		defer recoverStage(ctx)
+		for v := range stream {
+			val, err := fn(ctx, v)
+			if err != nil {
+				g.fail(err)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- val:
+			}
+		}
+	}()
+	return out, g
+}
+
+// ParallelMap returns a channel that emits the results of applying fn to each
+// value from the input stream, in input order, with at most workers calls to
+// fn running at once. If workers is 0, it is treated as 1.
+//
+// Results that finish ahead of an earlier value are held in a reorder buffer.
+// The buffer holds 2*workers results by default; use [WithReorderBuffer] to
+// change it. Once the buffer is full, no new values are read from the input
+// stream until the oldest pending result has been emitted.
+func ParallelMap[T, U any](ctx context.Context, stream <-chan T, workers uint, fn func(context.Context, T) U, opts ...Option) <-chan U {
+	workers = max(workers, 1)
+	o := newOptions(opts)
+	size := o.reorderBuffer
+	if size == 0 {
+		size = 2 * workers
+	}
+	type job struct {
+		v      T
+		result chan U
+	}
+	jobs := make(chan job)
+	pending := make(chan chan U, size)
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for v := range stream {
+			j := job{v: v, result: make(chan U, 1)}
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- j.result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- j:
+			}
+		}
+	}()
+	for range workers {
+		go func() {
+			defer recoverStage(ctx)
+			for j := range jobs {
+				j.result <- fn(ctx, j.v)
+			}
+		}()
+	}
+	out := make(chan U, o.buffer)
+	go func() {
+		defer close(out)
+		for result := range pending {
+			var val U
+			select {
+			case <-ctx.Done():
+				return
+			case val = <-result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- val:
+			}
+		}
+	}()
+	return out
+}
+
+// batchPrealloc caps the capacity allocated for a new batch up front, so that
+// a large maxSize does not allocate memory for values that may never come.
+const batchPrealloc = 64
+
+// Batch returns a channel that emits the values from the input stream in
+// slices. A batch is emitted once it holds maxSize values, or once maxWait has
+// passed since its first value was received, whichever comes first. A partial
+// batch is emitted when the input stream closes, but not when the context is
+// canceled.
+//
+// If maxSize is 0, batches are bounded by maxWait only. If maxWait is 0 or
+// negative, batches are bounded by maxSize only.
+func Batch[T any](ctx context.Context, stream <-chan T, maxSize uint, maxWait time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	out := make(chan []T, o.buffer)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+		defer timer.Stop()
+		var (
+			batch   []T
+			expired <-chan time.Time
+		)
+		flush := func() bool {
+			timer.Stop()
+			expired = nil
+			b := batch
+			batch = nil
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- b:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				if batch == nil {
+					batch = make([]T, 0, min(maxSize, batchPrealloc))
+					if maxWait > 0 {
+						timer.Reset(maxWait)
+						expired = timer.C
+					}
+				}
+				batch = append(batch, v)
+				if maxSize > 0 && uint(len(batch)) >= maxSize && !flush() {
+					return
+				}
+			case <-expired:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -2,7 +2,11 @@ package conduit
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestTransformer(t *testing.T) {
@@ -21,6 +25,25 @@ func TestTransformer(t *testing.T) {
 			},
 			want: []int{10, 20, 30},
 		},
+		{
+			name: "TryMap",
+			setup: func(ctx context.Context) <-chan int {
+				stream, _ := TryMap(ctx, From(ctx, 1, 2, 3), func(ctx context.Context, v int) (int, error) {
+					return v * 10, nil
+				})
+				return Take(ctx, stream, 3)
+			},
+			want: []int{10, 20, 30},
+		},
+		{
+			name: "ParallelMap",
+			setup: func(ctx context.Context) <-chan int {
+				return ParallelMap(ctx, From(ctx, 1, 2, 3), 2, func(ctx context.Context, v int) int {
+					return v * 10
+				})
+			},
+			want: []int{10, 20, 30},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name+" ok", func(t *testing.T) {
@@ -34,3 +57,120 @@ func TestTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestTryMapError(t *testing.T) {
+	errBoom := errors.New("boom")
+	ctx := t.Context()
+	stream, g := TryMap(ctx, From(ctx, 1, 2, 3, 4), func(ctx context.Context, v int) (int, error) {
+		if v == 3 {
+			return 0, errBoom
+		}
+		return v, nil
+	})
+	var got []int
+	for v := range stream {
+		got = append(got, v)
+	}
+	checkStream(t, got, []int{1, 2})
+	if err := g.Wait(); !errors.Is(err, errBoom) {
+		t.Errorf("Wait() = %v, want %v", err, errBoom)
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	const workers = 4
+	var running, peak atomic.Int32
+	ctx := t.Context()
+	values := make([]int, 50)
+	for i := range values {
+		values[i] = i
+	}
+	stream := ParallelMap(ctx, From(ctx, values...), workers, func(_ context.Context, v int) int {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Later values finish first, so results must be reordered.
+		time.Sleep(time.Duration(len(values)-v) * 100 * time.Microsecond)
+		return v
+	}, WithReorderBuffer(workers))
+	var got []int
+	for v := range stream {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, values) {
+		t.Errorf("got %v, want %v", got, values)
+	}
+	if p := peak.Load(); p > workers {
+		t.Errorf("peak concurrency = %d, want <= %d", p, workers)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan []int
+		want  [][]int
+	}{
+		{
+			name: "by size",
+			setup: func(ctx context.Context) <-chan []int {
+				return Batch(ctx, From(ctx, 1, 2, 3, 4, 5), 2, 0)
+			},
+			want: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name: "by time",
+			setup: func(ctx context.Context) <-chan []int {
+				in := make(chan int)
+				go func() {
+					defer close(in)
+					in <- 1
+					in <- 2
+					time.Sleep(50 * time.Millisecond)
+					in <- 3
+				}()
+				return Batch(ctx, in, 10, 10*time.Millisecond)
+			},
+			want: [][]int{{1, 2}, {3}},
+		},
+		{
+			name: "unbounded",
+			setup: func(ctx context.Context) <-chan []int {
+				return Batch(ctx, From(ctx, 1, 2, 3), 0, 0)
+			},
+			want: [][]int{{1, 2, 3}},
+		},
+		{
+			name: "huge maxSize",
+			setup: func(ctx context.Context) <-chan []int {
+				return Batch(ctx, From(ctx, 1, 2, 3), ^uint(0), 0)
+			},
+			want: [][]int{{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			var got [][]int
+			for b := range tt.setup(t.Context()) {
+				got = append(got, b)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			for b := range tt.setup(ctx) {
+				t.Errorf("expected no batches after cancellation, got %v", b)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// TumblingWindow returns a channel that groups the values from the input
+// stream into consecutive, non-overlapping windows of the given size, based on
+// the time each value is received. Each window is emitted as a slice when it
+// ends; empty windows are not emitted. A partial window is emitted when the
+// input stream closes, but not when the context is canceled.
+//
+// TumblingWindow panics if size is not positive.
+func TumblingWindow[T any](ctx context.Context, stream <-chan T, size time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	out := make(chan []T, o.buffer)
+	ticker := time.NewTicker(size)
+	go func() {
+		defer close(out)
+		defer ticker.Stop()
+		var window []T
+		emit := func() bool {
+			w := window
+			window = nil
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- w:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					if len(window) > 0 {
+						emit()
+					}
+					return
+				}
+				window = append(window, v)
+			case <-ticker.C:
+				if len(window) > 0 && !emit() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// SlidingWindow returns a channel that groups the values from the input
+// stream into windows of the given size that start every slide, based on the
+// time each value is received. If slide is less than size, windows overlap and
+// a value can appear in more than one window; if it is greater, values
+// received between windows are dropped.
+//
+// Each window is emitted as a slice when it ends; empty windows are not
+// emitted. When the input stream closes, the values received within the last
+// size are emitted as a final window. Nothing is emitted once the context is
+// canceled.
+//
+// SlidingWindow panics if size or slide is not positive.
+func SlidingWindow[T any](ctx context.Context, stream <-chan T, size, slide time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	if size <= 0 {
+		panic("conduit: non-positive size for SlidingWindow")
+	}
+	type arrival struct {
+		at time.Time
+		v  T
+	}
+	out := make(chan []T, o.buffer)
+	ticker := time.NewTicker(slide)
+	go func() {
+		defer close(out)
+		defer ticker.Stop()
+		var arrivals []arrival
+		// emit evicts values received before now-size and emits the rest.
+		emit := func(now time.Time) bool {
+			start := now.Add(-size)
+			i := 0
+			for i < len(arrivals) && !arrivals[i].at.After(start) {
+				i++
+			}
+			arrivals = arrivals[i:]
+			if len(arrivals) == 0 {
+				return true
+			}
+			window := make([]T, len(arrivals))
+			for i, a := range arrivals {
+				window[i] = a.v
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- window:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					emit(time.Now())
+					return
+				}
+				arrivals = append(arrivals, arrival{at: time.Now(), v: v})
+			case now := <-ticker.C:
+				if !emit(now) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// A Window is a group of values whose event times fall within [Start, End).
+type Window[T any] struct {
+	Start, End time.Time
+	Values     []T
+}
+
+// EventTimeWindow returns a channel that groups the values from the input
+// stream into consecutive, non-overlapping windows of the given size, based on
+// the event time reported by timestamp for each value, and a channel of late
+// values.
+//
+// The stage tracks a watermark: the latest event time seen so far, minus the
+// out-of-orderness set with [WithOutOfOrderness]. A window is emitted once the
+// watermark passes its end. Values for an emitted window are still accepted
+// until the watermark passes its end plus the lateness set with
+// [WithAllowedLateness]; each such value causes the window to be emitted again
+// with all of its values. Values that arrive after that are sent to the late
+// channel instead. When the input stream closes, all pending windows are
+// emitted.
+//
+// Both returned channels must be consumed.
+//
+// EventTimeWindow panics if size is not positive.
+func EventTimeWindow[T any](ctx context.Context, stream <-chan T, size time.Duration, timestamp func(T) time.Time, opts ...Option) (_ <-chan Window[T], late <-chan T) {
+	if size <= 0 {
+		panic("conduit: non-positive size for EventTimeWindow")
+	}
+	o := newOptions(opts)
+	type state struct {
+		window Window[T]
+		fired  bool
+	}
+	out := make(chan Window[T], o.buffer)
+	lateOut := make(chan T, o.buffer)
+	go func() {
+		defer close(out)
+		defer close(lateOut)
+		defer recoverStage(ctx)
+		var (
+			windows = make(map[int64]*state)
+			// unfired holds the windows that have not been emitted yet, by
+			// end, and open the windows still accepting values, by end plus
+			// allowed lateness. Both are keyed by window start.
+			unfired   = newDeadlines[int64]()
+			open      = newDeadlines[int64]()
+			watermark time.Time
+		)
+		emit := func(s *state) bool {
+			s.fired = true
+			w := s.window
+			w.Values = slices.Clone(w.Values)
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- w:
+				return true
+			}
+		}
+		// advance fires, in start order, the windows that end at or before
+		// the watermark, and purges those whose lateness has run out too.
+		advance := func(final bool) bool {
+			for {
+				k, end, ok := unfired.peek()
+				if !ok || (!final && end.After(watermark)) {
+					break
+				}
+				unfired.remove(k)
+				if !emit(windows[k]) {
+					return false
+				}
+			}
+			for {
+				k, until, ok := open.peek()
+				if !ok || (!final && until.After(watermark)) {
+					break
+				}
+				open.remove(k)
+				delete(windows, k)
+			}
+			return true
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					advance(true)
+					return
+				}
+				ts := timestamp(v)
+				start := ts.Truncate(size)
+				end := start.Add(size)
+				if !end.Add(o.allowedLateness).After(watermark) {
+					select {
+					case <-ctx.Done():
+						return
+					case lateOut <- v:
+					}
+					continue
+				}
+				k := start.UnixNano()
+				s, ok := windows[k]
+				if !ok {
+					s = &state{window: Window[T]{Start: start, End: end}}
+					windows[k] = s
+					unfired.set(k, end)
+					open.set(k, end.Add(o.allowedLateness))
+				}
+				s.window.Values = append(s.window.Values, v)
+				if s.fired && !emit(s) {
+					return
+				}
+				if wm := ts.Add(-o.outOfOrderness); wm.After(watermark) {
+					watermark = wm
+				}
+				if !advance(false) {
+					return
+				}
+			}
+		}
+	}()
+	return out, lateOut
+}
+
+// SessionWindow returns a channel that groups the values from the input stream
+// into per-key sessions, where keyFn reports the key of each value. A session
+// ends once no value with its key has been received for the given gap, and is
+// then emitted as a slice. All open sessions are emitted when the input stream
+// closes, but not when the context is canceled.
+//
+// Sessions are timed by a single goroutine and timer, regardless of the number
+// of keys.
+//
+// SessionWindow panics if gap is not positive.
+func SessionWindow[T any, K comparable](ctx context.Context, stream <-chan T, keyFn func(T) K, gap time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	if gap <= 0 {
+		panic("conduit: non-positive gap for SessionWindow")
+	}
+	out := make(chan []T, o.buffer)
+	go func() {
+		defer close(out)
+		defer recoverStage(ctx)
+		timer := time.NewTimer(gap)
+		timer.Stop()
+		defer timer.Stop()
+		var (
+			sessions = make(map[K][]T)
+			expiry   = newDeadlines[K]()
+			expired  <-chan time.Time
+		)
+		emit := func(key K) bool {
+			session := sessions[key]
+			delete(sessions, key)
+			expiry.remove(key)
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- session:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-stream:
+				if !ok {
+					for key, _, ok := expiry.peek(); ok; key, _, ok = expiry.peek() {
+						if !emit(key) {
+							return
+						}
+					}
+					return
+				}
+				key := keyFn(v)
+				sessions[key] = append(sessions[key], v)
+				expiry.set(key, time.Now().Add(gap))
+			case <-expired:
+				now := time.Now()
+				for key, at, ok := expiry.peek(); ok && !at.After(now); key, at, ok = expiry.peek() {
+					if !emit(key) {
+						return
+					}
+				}
+			}
+			expired = expiry.reset(timer)
+		}
+	}()
+	return out
+}
@@ -0,0 +1,212 @@
+package conduit
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// sendAfter returns a channel that emits each value after its delay has
+// passed since the previous value, then closes after the final delay.
+func sendAfter(ctx context.Context, values []int, delays []time.Duration) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i, d := range delays {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(d):
+			}
+			if i == len(values) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- values[i]:
+			}
+		}
+	}()
+	return out
+}
+
+func TestWindow(t *testing.T) {
+	const unit = 50 * time.Millisecond
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan []int
+		want  [][]int
+	}{
+		{
+			name: "TumblingWindow",
+			setup: func(ctx context.Context) <-chan []int {
+				stream := sendAfter(ctx, []int{1, 2, 3}, []time.Duration{0, 0, 3 * unit, 0})
+				return TumblingWindow(ctx, stream, 2*unit)
+			},
+			want: [][]int{{1, 2}, {3}},
+		},
+		{
+			name: "SlidingWindow",
+			setup: func(ctx context.Context) <-chan []int {
+				// Arrivals fall half a unit away from the ticks, so that tick
+				// latency does not move them to another window.
+				stream := sendAfter(ctx, []int{1, 2}, []time.Duration{unit / 2, unit, 4 * unit})
+				return SlidingWindow(ctx, stream, 2*unit, unit)
+			},
+			want: [][]int{{1}, {1, 2}, {2}},
+		},
+		{
+			name: "SlidingWindow flush",
+			setup: func(ctx context.Context) <-chan []int {
+				return SlidingWindow(ctx, From(ctx, 1, 2, 3), 2*unit, unit)
+			},
+			want: [][]int{{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			var got [][]int
+			for w := range tt.setup(t.Context()) {
+				got = append(got, w)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			for w := range tt.setup(ctx) {
+				t.Errorf("expected no windows after cancellation, got %v", w)
+			}
+		})
+	}
+}
+
+func TestEventTimeWindow(t *testing.T) {
+	at := func(sec int) time.Time { return time.Unix(int64(sec), 0) }
+	timestamp := func(sec int) time.Time { return at(sec) }
+	setup := func(ctx context.Context) (<-chan Window[int], <-chan int) {
+		stream := From(ctx, 1, 2, 12, 5, 25, 3)
+		return EventTimeWindow(ctx, stream, 10*time.Second, timestamp,
+			WithAllowedLateness(5*time.Second))
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		windows, late := setup(t.Context())
+		var lateValues []int
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for v := range late {
+				lateValues = append(lateValues, v)
+			}
+		}()
+		var got []Window[int]
+		for w := range windows {
+			got = append(got, w)
+		}
+		<-done
+		want := []Window[int]{
+			{Start: at(0), End: at(10), Values: []int{1, 2}},
+			{Start: at(0), End: at(10), Values: []int{1, 2, 5}},
+			{Start: at(10), End: at(20), Values: []int{12}},
+			{Start: at(20), End: at(30), Values: []int{25}},
+		}
+		if !slices.EqualFunc(got, want, func(a, b Window[int]) bool {
+			return a.Start.Equal(b.Start) && a.End.Equal(b.End) && slices.Equal(a.Values, b.Values)
+		}) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if !slices.Equal(lateValues, []int{3}) {
+			t.Errorf("late values = %v, want [3]", lateValues)
+		}
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		t.Parallel()
+		ctx := t.Context()
+		stream := From(ctx, 8, 11, 9, 22)
+		windows, late := EventTimeWindow(ctx, stream, 10*time.Second, timestamp,
+			WithOutOfOrderness(2*time.Second))
+		go func() {
+			for v := range late {
+				t.Errorf("unexpected late value %d", v)
+			}
+		}()
+		var got [][]int
+		for w := range windows {
+			got = append(got, w.Values)
+		}
+		if want := [][]int{{8, 9}, {11}, {22}}; !slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		windows, late := setup(ctx)
+		for w := range windows {
+			t.Errorf("expected no windows after cancellation, got %v", w)
+		}
+		for v := range late {
+			t.Errorf("expected no late values after cancellation, got %v", v)
+		}
+	})
+}
+
+func TestSessionWindow(t *testing.T) {
+	const gap = 50 * time.Millisecond
+	key := func(v int) int { return v / 10 }
+	tests := []struct {
+		name  string
+		setup func(ctx context.Context) <-chan []int
+		want  [][]int
+	}{
+		{
+			name: "SessionWindow",
+			setup: func(ctx context.Context) <-chan []int {
+				stream := sendAfter(ctx, []int{11, 21, 12, 13}, []time.Duration{0, 0, 0, 3 * gap, 0})
+				return SessionWindow(ctx, stream, key, gap)
+			},
+			want: [][]int{{21}, {11, 12}, {13}},
+		},
+		{
+			name: "SessionWindow flush",
+			setup: func(ctx context.Context) <-chan []int {
+				return SessionWindow(ctx, From(ctx, 11, 21, 12), key, time.Hour)
+			},
+			want: [][]int{{11, 12}, {21}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" ok", func(t *testing.T) {
+			t.Parallel()
+			var got [][]int
+			for w := range tt.setup(t.Context()) {
+				got = append(got, w)
+			}
+			slices.SortFunc(got, func(a, b []int) int { return a[0] - b[0] })
+			want := slices.Clone(tt.want)
+			slices.SortFunc(want, func(a, b []int) int { return a[0] - b[0] })
+			if !slices.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+		t.Run(tt.name+" cancelled", func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			for w := range tt.setup(ctx) {
+				t.Errorf("expected no sessions after cancellation, got %v", w)
+			}
+		})
+	}
+}